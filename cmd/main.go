@@ -4,8 +4,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/go-chi/chi/v5"
+	"github.com/v-vovk/health-tracker-api/internal/app/achievement"
+	"github.com/v-vovk/health-tracker-api/internal/app/activity"
+	"github.com/v-vovk/health-tracker-api/internal/app/body"
+	"github.com/v-vovk/health-tracker-api/internal/app/brand"
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/app/fasting"
 	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/app/glucose"
+	"github.com/v-vovk/health-tracker-api/internal/app/goal"
 	"github.com/v-vovk/health-tracker-api/internal/app/group"
+	"github.com/v-vovk/health-tracker-api/internal/app/hydration"
+	"github.com/v-vovk/health-tracker-api/internal/app/medication"
+	"github.com/v-vovk/health-tracker-api/internal/app/nutrient"
+	"github.com/v-vovk/health-tracker-api/internal/app/recipe"
+	"github.com/v-vovk/health-tracker-api/internal/app/session"
+	"github.com/v-vovk/health-tracker-api/internal/app/sleep"
+	"github.com/v-vovk/health-tracker-api/internal/app/symptom"
+	"github.com/v-vovk/health-tracker-api/internal/app/user"
+	"github.com/v-vovk/health-tracker-api/internal/app/vitals"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
 	"github.com/v-vovk/health-tracker-api/internal/infra/config"
 	"github.com/v-vovk/health-tracker-api/internal/infra/db"
 	"github.com/v-vovk/health-tracker-api/internal/infra/logger"
@@ -29,9 +47,6 @@ func main() {
 	if database == nil {
 		log.Fatal("Failed to connect to the database")
 	}
-	if err := database.AutoMigrate(&food.Food{}); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
-	}
 
 	r := chi.NewRouter()
 
@@ -46,11 +61,77 @@ func main() {
 		}
 	})
 
-	foodHandler := food.NewHandlerFactory(database, logger.Log)
-	r.Mount("/foods", foodHandler.Routes())
+	if len(cfg.JWTSecret) < auth.MinSecretLength {
+		log.Fatalf("JWT_SECRET must be at least %d bytes", auth.MinSecretLength)
+	}
+	issuer := auth.NewIssuer(cfg.JWTSecret, auth.AccessTokenTTL)
+	authenticate := auth.Middleware(issuer)
+
+	userHandler := user.NewHandlerFactory(database, logger.Log)
+	r.Mount("/users", userHandler.Routes(authenticate))
+
+	sessionHandler := session.NewHandlerFactory(database, logger.Log, userHandler.Service, issuer)
+	r.Mount("/auth", sessionHandler.Routes(authenticate))
+
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(userHandler.DefaultLocation)
+
+		nutrientHandler := nutrient.NewHandlerFactory(database, logger.Log)
+		r.Mount("/nutrients", nutrientHandler.Routes())
+
+		brandHandler := brand.NewHandlerFactory(database, logger.Log)
+		r.Mount("/brands", brandHandler.Routes())
+		r.Mount("/manufacturers", brandHandler.ManufacturerRoutes())
+
+		groupHandler := group.NewHandlerFactory(database, logger.Log)
+		r.Mount("/groups", groupHandler.Routes())
+
+		foodHandler := food.NewHandlerFactory(database, logger.Log)
+		foodRouter := foodHandler.Routes()
+		foodRouter.Mount("/{id}/nutrients", nutrientHandler.FoodRoutes())
+		foodRouter.Mount("/{id}/groups", groupHandler.FoodRoutes())
+		r.Mount("/foods", foodRouter)
+
+		recipeHandler := recipe.NewHandlerFactory(database, logger.Log, foodHandler.Service, nutrientHandler.Service)
+		r.Mount("/recipes", recipeHandler.Routes())
+
+		hydrationHandler := hydration.NewHandlerFactory(database, logger.Log)
+		r.Mount("/hydration", hydrationHandler.Routes())
 
-	groupHandler := group.NewHandlerFactory(database, logger.Log)
-	r.Mount("/groups", groupHandler.Routes())
+		medicationHandler := medication.NewHandlerFactory(database, logger.Log)
+		r.Mount("/medications", medicationHandler.Routes())
+
+		diaryHandler := diary.NewHandlerFactory(database, logger.Log, foodHandler.Service, hydrationHandler.Service, medicationHandler.Service)
+		r.Mount("/diary", diaryHandler.Routes())
+
+		goalHandler := goal.NewHandlerFactory(database, logger.Log, diaryHandler.Service)
+		r.Mount("/goals", goalHandler.Routes())
+
+		bodyHandler := body.NewHandlerFactory(database, logger.Log)
+		r.Mount("/body", bodyHandler.Routes())
+
+		activityHandler := activity.NewHandlerFactory(database, logger.Log, bodyHandler.Service, diaryHandler.Service)
+		r.Mount("/activity", activityHandler.Routes())
+
+		sleepHandler := sleep.NewHandlerFactory(database, logger.Log)
+		r.Mount("/sleep", sleepHandler.Routes())
+
+		glucoseHandler := glucose.NewHandlerFactory(database, logger.Log, diaryHandler.Service)
+		r.Mount("/glucose", glucoseHandler.Routes())
+
+		vitalsHandler := vitals.NewHandlerFactory(database, logger.Log)
+		r.Mount("/vitals", vitalsHandler.Routes())
+
+		symptomHandler := symptom.NewHandlerFactory(database, logger.Log)
+		r.Mount("/symptoms", symptomHandler.Routes())
+
+		fastingHandler := fasting.NewHandlerFactory(database, logger.Log, diaryHandler.Service)
+		r.Mount("/fasting", fastingHandler.Routes())
+
+		achievementHandler := achievement.NewHandlerFactory(database, logger.Log, diaryHandler.Service, goalHandler.Service, userHandler.Service)
+		r.Mount("/achievements", achievementHandler.Routes())
+	})
 
 	port := fmt.Sprintf(":%s", cfg.AppPort)
 	logger.Log.Info("Server is starting", zap.String("port", port))
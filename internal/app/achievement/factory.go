@@ -0,0 +1,21 @@
+package achievement
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/app/goal"
+	"github.com/v-vovk/health-tracker-api/internal/app/user"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger, diary diary.Service, goals goal.Service, users user.Service) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, diary, goals, users)
+	diary.Subscribe(service)
+	users.Subscribe(service)
+	validator := validator.New()
+	achievementLogger := logger.Named("AchievementHandler")
+
+	return NewHandler(service, validator, achievementLogger)
+}
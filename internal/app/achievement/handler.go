@@ -0,0 +1,296 @@
+package achievement
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	rules, total, err := h.Service.GetRules(userID, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving achievement rules")
+		h.Logger.Error("Error retrieving achievement rules", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     rules,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(rules),
+	}
+
+	h.Logger.Info("Retrieved achievement rules", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(rules)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var rule Rule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(rule); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateRule(userID, &rule); err != nil {
+		if err == ErrUnknownNutrient {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Unknown goal nutrient")
+			h.Logger.Warn("Unknown goal nutrient", zap.String("nutrient", rule.Nutrient))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating achievement rule")
+		h.Logger.Error("Error creating achievement rule", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Created new achievement rule", zap.String("id", rule.ID), zap.String("kind", rule.Kind), zap.Int("days", rule.Days))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetRuleByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing achievement rule ID")
+		h.Logger.Warn("Missing achievement rule ID in request")
+		return
+	}
+
+	rule, err := h.Service.GetRuleByID(userID, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Achievement rule not found")
+			h.Logger.Warn("Achievement rule not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving achievement rule")
+		h.Logger.Error("Error retrieving achievement rule", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved achievement rule", zap.String("id", rule.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing achievement rule ID")
+		h.Logger.Warn("Missing achievement rule ID in request")
+		return
+	}
+
+	var updatedData Rule
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateRule(userID, &updatedData); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Achievement rule not found")
+			h.Logger.Warn("Achievement rule not found", zap.String("id", id))
+			return
+		}
+		if err == ErrUnknownNutrient {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Unknown goal nutrient")
+			h.Logger.Warn("Unknown goal nutrient", zap.String("nutrient", updatedData.Nutrient))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating achievement rule")
+		h.Logger.Error("Error updating achievement rule", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Updated achievement rule", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing achievement rule ID")
+		h.Logger.Warn("Missing achievement rule ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteRule(userID, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Achievement rule not found")
+			h.Logger.Warn("Achievement rule not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting achievement rule")
+		h.Logger.Error("Error deleting achievement rule", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Deleted achievement rule", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetBadges(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	badges, total, err := h.Service.GetBadges(userID, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving badges")
+		h.Logger.Error("Error retrieving badges", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     badges,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(badges),
+	}
+
+	h.Logger.Info("Retrieved badges", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(badges)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	progress, err := h.Service.GetProgress(userID, time.Now())
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating achievement progress")
+		h.Logger.Error("Error calculating achievement progress", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Calculated achievement progress", zap.Int("rules", len(progress)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(progress); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
@@ -0,0 +1,107 @@
+package achievement
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+)
+
+// Rule kinds decide which days count towards a rule.
+const (
+	// KindMealsLogged counts days with entries in every meal type of MealTypes,
+	// by default DefaultMealTypes.
+	KindMealsLogged = "meals_logged"
+	// KindGoalMet counts days on which the goal target for Nutrient is met.
+	KindGoalMet = "goal_met"
+)
+
+// DefaultMealTypes are the meals a meals_logged rule needs without MealTypes.
+var DefaultMealTypes = []string{"breakfast", "lunch", "dinner"}
+
+// Rule modes decide how the counted days add up to Days.
+const (
+	// ModeStreak needs Days consecutive days.
+	ModeStreak = "streak"
+	// ModeTotal needs Days days in all.
+	ModeTotal = "total"
+)
+
+// DefaultRules are created for every account when it registers, in the
+// account's time zone.
+var DefaultRules = []Rule{
+	{
+		Name:        "Every meal for a week",
+		Description: "Logged breakfast, lunch and dinner 7 days in a row.",
+		Kind:        KindMealsLogged,
+		Mode:        ModeStreak,
+		Days:        7,
+	},
+	{
+		Name:        "Protein goal 30 days",
+		Description: "Hit the protein goal on 30 days.",
+		Kind:        KindGoalMet,
+		Nutrient:    "protein_g",
+		Mode:        ModeTotal,
+		Days:        30,
+	},
+}
+
+// Rule awards a badge once enough days count towards it, e.g. every meal
+// logged 7 days in a row. Days are calendar days in TimeZone.
+type Rule struct {
+	ID          string         `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID      string         `json:"-" gorm:"type:uuid;not null"`
+	Name        string         `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	Description string         `json:"description" gorm:"not null;default:''" validate:"max=1000"`
+	Kind        string         `json:"kind" gorm:"not null" validate:"required,oneof=meals_logged goal_met"`
+	MealTypes   db.StringArray `json:"meal_types" gorm:"type:text[];not null;default:'{}'" validate:"unique,dive,oneof=breakfast lunch dinner snack custom"`
+	Nutrient    string         `json:"nutrient" gorm:"not null;default:''" validate:"required_if=Kind goal_met,max=50"`
+	Mode        string         `json:"mode" gorm:"not null;default:'streak'" validate:"omitempty,oneof=streak total"`
+	Days        int            `json:"days" gorm:"not null" validate:"required,min=1,max=3650"`
+	TimeZone    string         `json:"time_zone" gorm:"not null;default:'UTC'" validate:"omitempty,timezone"`
+	Badge       *Badge         `json:"badge" gorm:"foreignKey:RuleID" validate:"-"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Rule) TableName() string {
+	return "achievement_rules"
+}
+
+// Day is a local day that counts towards a rule. Days are re-evaluated whenever
+// their entries change, and dropped once they no longer qualify.
+type Day struct {
+	RuleID string  `gorm:"type:uuid;primaryKey"`
+	Date   db.Date `gorm:"primaryKey"`
+}
+
+func (Day) TableName() string {
+	return "achievement_days"
+}
+
+// Badge is a rule's award. Date is the local day that completed the rule and
+// Days the streak or total reached then.
+type Badge struct {
+	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	RuleID    string    `json:"rule_id" gorm:"type:uuid;not null;uniqueIndex"`
+	Rule      *Rule     `json:"rule,omitempty"`
+	Date      db.Date   `json:"date" gorm:"not null"`
+	Days      int       `json:"days" gorm:"not null"`
+	AwardedAt time.Time `json:"awarded_at" gorm:"autoCreateTime"`
+}
+
+func (Badge) TableName() string {
+	return "achievement_badges"
+}
+
+// Progress reports how far a rule has come. For streak rules Days is the
+// current streak, which stays alive until a day in the rule's time zone passes
+// without counting; Best is the longest streak so far.
+type Progress struct {
+	Rule    Rule    `json:"rule"`
+	Days    int     `json:"days"`
+	Best    int     `json:"best"`
+	Target  int     `json:"target"`
+	Percent float64 `json:"percent"`
+	Awarded bool    `json:"awarded"`
+}
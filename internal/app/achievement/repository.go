@@ -0,0 +1,126 @@
+package achievement
+
+import (
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetRules(userID string, limit, offset int) ([]Rule, int64, error)
+	GetAllRules(userID string) ([]Rule, error)
+	GetPendingRules(userID string) ([]Rule, error)
+	GetRuleByID(userID, id string) (*Rule, error)
+	CreateRule(rule *Rule) error
+	UpdateRule(rule *Rule) error
+	DeleteRule(userID, id string) error
+
+	AddDay(day *Day) error
+	DeleteDay(ruleID string, date db.Date) error
+	GetDays(ruleID string) ([]db.Date, error)
+	DeleteDays(ruleID string) error
+
+	GetBadges(userID string, limit, offset int) ([]Badge, int64, error)
+	CreateBadge(badge *Badge) error
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetRules(userID string, limit, offset int) ([]Rule, int64, error) {
+	var rules []Rule
+	var total int64
+
+	if err := r.db.Model(&Rule{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Preload("Badge").Where("user_id = ?", userID).Order("name").Limit(limit).Offset(offset).Find(&rules).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return rules, total, nil
+}
+
+func (r *repositoryImpl) GetAllRules(userID string) ([]Rule, error) {
+	var rules []Rule
+	err := r.db.Preload("Badge").Where("user_id = ?", userID).Order("name").Find(&rules).Error
+	return rules, err
+}
+
+// GetPendingRules returns the user's rules that have not been awarded yet.
+func (r *repositoryImpl) GetPendingRules(userID string) ([]Rule, error) {
+	var rules []Rule
+	err := r.db.
+		Where("user_id = ?", userID).
+		Where("NOT EXISTS (SELECT 1 FROM achievement_badges b WHERE b.rule_id = achievement_rules.id)").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *repositoryImpl) GetRuleByID(userID, id string) (*Rule, error) {
+	var rule Rule
+	if err := r.db.Preload("Badge").First(&rule, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *repositoryImpl) CreateRule(rule *Rule) error {
+	return r.db.Omit("Badge").Create(rule).Error
+}
+
+func (r *repositoryImpl) UpdateRule(rule *Rule) error {
+	return r.db.Omit("Badge").Save(rule).Error
+}
+
+// DeleteRule deletes a rule; its days and badge are removed by the database.
+func (r *repositoryImpl) DeleteRule(userID, id string) error {
+	return r.db.Delete(&Rule{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) AddDay(day *Day) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(day).Error
+}
+
+func (r *repositoryImpl) DeleteDay(ruleID string, date db.Date) error {
+	return r.db.Delete(&Day{}, "rule_id = ? AND date = ?", ruleID, date).Error
+}
+
+// GetDays returns the days counted towards a rule in ascending order.
+func (r *repositoryImpl) GetDays(ruleID string) ([]db.Date, error) {
+	var dates []db.Date
+	err := r.db.Model(&Day{}).Where("rule_id = ?", ruleID).Order("date").Pluck("date", &dates).Error
+	return dates, err
+}
+
+func (r *repositoryImpl) DeleteDays(ruleID string) error {
+	return r.db.Delete(&Day{}, "rule_id = ?", ruleID).Error
+}
+
+func (r *repositoryImpl) GetBadges(userID string, limit, offset int) ([]Badge, int64, error) {
+	var badges []Badge
+	var total int64
+
+	query := r.db.Model(&Badge{}).
+		Where("rule_id IN (?)", r.db.Model(&Rule{}).Select("id").Where("user_id = ?", userID))
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Rule").Order("awarded_at DESC").Limit(limit).Offset(offset).Find(&badges).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return badges, total, nil
+}
+
+// CreateBadge awards a badge unless its rule already has one.
+func (r *repositoryImpl) CreateBadge(badge *Badge) error {
+	return r.db.Omit("Rule").Clauses(clause.OnConflict{DoNothing: true}).Create(badge).Error
+}
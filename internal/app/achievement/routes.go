@@ -0,0 +1,18 @@
+package achievement
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/rules", h.GetRules)
+	r.Post("/rules", h.CreateRule)
+	r.Get("/rules/{id}", h.GetRuleByID)
+	r.Put("/rules/{id}", h.UpdateRule)
+	r.Delete("/rules/{id}", h.DeleteRule)
+
+	r.Get("/badges", h.GetBadges)
+	r.Get("/progress", h.GetProgress)
+
+	return r
+}
@@ -0,0 +1,306 @@
+package achievement
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/app/goal"
+	"github.com/v-vovk/health-tracker-api/internal/app/user"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// maxMealsPerDay bounds the meals read to check a meals_logged day.
+const maxMealsPerDay = 100
+
+var ErrUnknownNutrient = errors.New("unknown goal nutrient")
+
+type Service interface {
+	GetRules(userID string, limit, offset int) ([]Rule, int64, error)
+	GetRuleByID(userID, id string) (*Rule, error)
+	CreateRule(userID string, rule *Rule) error
+	// UpdateRule starts counting afresh when what counts has changed; an
+	// awarded badge is kept.
+	UpdateRule(userID string, rule *Rule) error
+	DeleteRule(userID, id string) error
+
+	GetBadges(userID string, limit, offset int) ([]Badge, int64, error)
+	GetProgress(userID string, now time.Time) ([]Progress, error)
+
+	// EntriesLogged re-evaluates the days of the entries for the user's rules
+	// without a badge, and awards the rules that are complete.
+	EntriesLogged(userID string, entries []diary.Entry) error
+	// EntriesRemoved re-evaluates the days of the entries like EntriesLogged.
+	EntriesRemoved(userID string, entries []diary.Entry) error
+
+	// UserRegistered creates the DefaultRules for a new account.
+	UserRegistered(account *user.User) error
+}
+
+type serviceImpl struct {
+	repo  Repository
+	diary diary.Service
+	goals goal.Service
+	users user.Service
+}
+
+func NewService(repo Repository, diary diary.Service, goals goal.Service, users user.Service) Service {
+	return &serviceImpl{repo: repo, diary: diary, goals: goals, users: users}
+}
+
+func (s *serviceImpl) GetRules(userID string, limit, offset int) ([]Rule, int64, error) {
+	return s.repo.GetRules(userID, limit, offset)
+}
+
+func (s *serviceImpl) GetRuleByID(userID, id string) (*Rule, error) {
+	return s.repo.GetRuleByID(userID, id)
+}
+
+func (s *serviceImpl) CreateRule(userID string, rule *Rule) error {
+	rule.UserID = userID
+	if err := s.prepareRule(rule); err != nil {
+		return err
+	}
+	if err := s.repo.CreateRule(rule); err != nil {
+		return err
+	}
+
+	created, err := s.repo.GetRuleByID(userID, rule.ID)
+	if err != nil {
+		return err
+	}
+	*rule = *created
+	return nil
+}
+
+func (s *serviceImpl) UpdateRule(userID string, rule *Rule) error {
+	existingRule, err := s.repo.GetRuleByID(userID, rule.ID)
+	if err != nil {
+		return err
+	}
+
+	rule.UserID = userID
+	if err := s.prepareRule(rule); err != nil {
+		return err
+	}
+	rule.CreatedAt = existingRule.CreatedAt
+	if err := s.repo.UpdateRule(rule); err != nil {
+		return err
+	}
+
+	if rule.Kind != existingRule.Kind || rule.Nutrient != existingRule.Nutrient ||
+		rule.TimeZone != existingRule.TimeZone || !slices.Equal(rule.MealTypes, existingRule.MealTypes) {
+		if err := s.repo.DeleteDays(rule.ID); err != nil {
+			return err
+		}
+	}
+
+	updated, err := s.repo.GetRuleByID(userID, rule.ID)
+	if err != nil {
+		return err
+	}
+	*rule = *updated
+	return nil
+}
+
+func (s *serviceImpl) DeleteRule(userID, id string) error {
+	if _, err := s.repo.GetRuleByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteRule(userID, id)
+}
+
+func (s *serviceImpl) GetBadges(userID string, limit, offset int) ([]Badge, int64, error) {
+	return s.repo.GetBadges(userID, limit, offset)
+}
+
+func (s *serviceImpl) GetProgress(userID string, now time.Time) ([]Progress, error) {
+	rules, err := s.repo.GetAllRules(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]Progress, 0, len(rules))
+	for _, rule := range rules {
+		location, err := time.LoadLocation(rule.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		dates, err := s.repo.GetDays(rule.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		item := Progress{Rule: rule, Target: rule.Days, Awarded: rule.Badge != nil}
+		if rule.Mode == ModeTotal {
+			item.Days = len(dates)
+			item.Best = len(dates)
+		} else {
+			item.Days = currentRun(dates, db.NewDate(now.In(location)))
+			item.Best = longestRun(dates)
+		}
+		item.Percent = reporting.Round(min(float64(item.Days)/float64(rule.Days)*100, 100), 1)
+		progress = append(progress, item)
+	}
+	return progress, nil
+}
+
+func (s *serviceImpl) EntriesLogged(userID string, entries []diary.Entry) error {
+	return s.reevaluate(userID, entries, time.Now())
+}
+
+func (s *serviceImpl) EntriesRemoved(userID string, entries []diary.Entry) error {
+	return s.reevaluate(userID, entries, time.Now())
+}
+
+func (s *serviceImpl) UserRegistered(account *user.User) error {
+	for _, rule := range DefaultRules {
+		rule.UserID = account.ID
+		rule.TimeZone = account.TimeZone
+		if err := s.prepareRule(&rule); err != nil {
+			return err
+		}
+		if err := s.repo.CreateRule(&rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *serviceImpl) reevaluate(userID string, entries []diary.Entry, now time.Time) error {
+	rules, err := s.repo.GetPendingRules(userID)
+	if err != nil {
+		return err
+	}
+
+	times := make([]time.Time, 0, len(entries))
+	for _, entry := range entries {
+		times = append(times, entry.ConsumedAt)
+	}
+
+	for _, rule := range rules {
+		location, err := time.LoadLocation(rule.TimeZone)
+		if err != nil {
+			return err
+		}
+		for _, day := range localDays(times, location) {
+			if err := s.evaluate(&rule, day, location); err != nil {
+				return err
+			}
+		}
+		if err := s.award(&rule, db.NewDate(now.In(location))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluate counts the day towards the rule while it qualifies and stops
+// counting it once it no longer does.
+func (s *serviceImpl) evaluate(rule *Rule, day db.Date, location *time.Location) error {
+	qualifies, err := s.qualifies(rule, day.In(location))
+	if err != nil {
+		return err
+	}
+	if qualifies {
+		return s.repo.AddDay(&Day{RuleID: rule.ID, Date: day})
+	}
+	return s.repo.DeleteDay(rule.ID, day)
+}
+
+// award creates the rule's badge once its counted days reach the target. A
+// goal_met day can stop counting until it is over, e.g. when a maximum is
+// exceeded later on, so only the days before today count for those rules.
+func (s *serviceImpl) award(rule *Rule, today db.Date) error {
+	dates, err := s.repo.GetDays(rule.ID)
+	if err != nil {
+		return err
+	}
+	if rule.Kind == KindGoalMet {
+		dates = before(dates, today)
+	}
+
+	day, completed := completedOn(dates, rule.Mode, rule.Days)
+	if !completed {
+		return nil
+	}
+	return s.repo.CreateBadge(&Badge{RuleID: rule.ID, Date: day, Days: rule.Days})
+}
+
+// qualifies reports whether the day starting at the given local midnight
+// counts towards the rule, judged by the diary and goals of the rule's user.
+func (s *serviceImpl) qualifies(rule *Rule, start time.Time) (bool, error) {
+	switch rule.Kind {
+	case KindMealsLogged:
+		period := reporting.Range{From: start, To: start.AddDate(0, 0, 1)}
+		meals, _, err := s.diary.GetMeals(rule.UserID, period, maxMealsPerDay, 0)
+		if err != nil {
+			return false, err
+		}
+		logged := make(map[string]bool)
+		for _, meal := range meals {
+			if len(meal.Entries) > 0 {
+				logged[meal.Type] = true
+			}
+		}
+		for _, mealType := range rule.MealTypes {
+			if !logged[mealType] {
+				return false, nil
+			}
+		}
+		return true, nil
+
+	case KindGoalMet:
+		progress, err := s.goals.GetProgress(rule.UserID, start)
+		if errors.Is(err, goal.ErrNoGoal) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, nutrient := range progress.Nutrients {
+			if nutrient.Nutrient == rule.Nutrient {
+				return nutrient.Status == goal.StatusMet, nil
+			}
+		}
+		return false, nil
+	}
+	return false, nil
+}
+
+// prepareRule checks the goal_met nutrient, defaults the mode, meal types and
+// time zone, the latter to the user's, and drops the fields the kind does not use.
+func (s *serviceImpl) prepareRule(rule *Rule) error {
+	if rule.Kind == KindGoalMet {
+		if err := s.goals.CheckNutrient(rule.Nutrient); err != nil {
+			if errors.Is(err, goal.ErrUnknownNutrient) {
+				return ErrUnknownNutrient
+			}
+			return err
+		}
+	}
+
+	if rule.Mode == "" {
+		rule.Mode = ModeStreak
+	}
+	if rule.TimeZone == "" {
+		account, err := s.users.GetByID(rule.UserID)
+		if err != nil {
+			return err
+		}
+		rule.TimeZone = account.TimeZone
+	}
+	switch {
+	case rule.Kind != KindMealsLogged:
+		rule.MealTypes = db.StringArray{}
+	case len(rule.MealTypes) == 0:
+		rule.MealTypes = slices.Clone(DefaultMealTypes)
+	}
+	if rule.Kind != KindGoalMet {
+		rule.Nutrient = ""
+	}
+	rule.Badge = nil
+	return nil
+}
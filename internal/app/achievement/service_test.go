@@ -0,0 +1,129 @@
+package achievement
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/goal"
+	"github.com/v-vovk/health-tracker-api/internal/app/user"
+)
+
+// fakeRepository records the rules created.
+type fakeRepository struct {
+	Repository
+	created []Rule
+}
+
+func (r *fakeRepository) CreateRule(rule *Rule) error {
+	r.created = append(r.created, *rule)
+	return nil
+}
+
+func (r *fakeRepository) GetRuleByID(userID, id string) (*Rule, error) {
+	rule := r.created[len(r.created)-1]
+	return &rule, nil
+}
+
+// fakeGoals knows the protein_g and iron_mg nutrients.
+type fakeGoals struct {
+	goal.Service
+}
+
+func (fakeGoals) CheckNutrient(code string) error {
+	if code != "protein_g" && code != "iron_mg" {
+		return goal.ErrUnknownNutrient
+	}
+	return nil
+}
+
+// fakeUsers serves profiles in Europe/Kyiv.
+type fakeUsers struct {
+	user.Service
+}
+
+func (fakeUsers) GetByID(id string) (*user.User, error) {
+	return &user.User{ID: id, TimeZone: "Europe/Kyiv"}, nil
+}
+
+func TestUserRegistered(t *testing.T) {
+	repo := &fakeRepository{}
+	service := NewService(repo, nil, fakeGoals{}, nil)
+
+	account := &user.User{ID: "user-1", TimeZone: "Europe/Kyiv"}
+	if err := service.UserRegistered(account); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.UserRegistered(&user.User{ID: "user-2", TimeZone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.created) != 2*len(DefaultRules) {
+		t.Fatalf("created %d rules, want %d", len(repo.created), 2*len(DefaultRules))
+	}
+	for i, rule := range repo.created[:len(DefaultRules)] {
+		if rule.UserID != account.ID || rule.TimeZone != account.TimeZone {
+			t.Errorf("rule %q for %s in %s, want %s in %s", rule.Name, rule.UserID, rule.TimeZone, account.ID, account.TimeZone)
+		}
+		if rule.Name != DefaultRules[i].Name {
+			t.Errorf("rule %d = %q, want %q", i, rule.Name, DefaultRules[i].Name)
+		}
+	}
+	if meals := repo.created[0].MealTypes; !slices.Equal(meals, DefaultMealTypes) {
+		t.Errorf("meal types = %v, want %v", meals, DefaultMealTypes)
+	}
+	if DefaultRules[0].UserID != "" || DefaultRules[0].MealTypes != nil {
+		t.Error("UserRegistered changed DefaultRules")
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		timeZone string
+		err      error
+	}{
+		{
+			name:     "time zone defaults to the profile's",
+			rule:     Rule{Kind: KindMealsLogged, Days: 7},
+			timeZone: "Europe/Kyiv",
+		},
+		{
+			name:     "given time zone is kept",
+			rule:     Rule{Kind: KindMealsLogged, Days: 7, TimeZone: "America/New_York"},
+			timeZone: "America/New_York",
+		},
+		{
+			name:     "catalogue nutrient",
+			rule:     Rule{Kind: KindGoalMet, Nutrient: "iron_mg", Days: 30},
+			timeZone: "Europe/Kyiv",
+		},
+		{
+			name: "unknown nutrient",
+			rule: Rule{Kind: KindGoalMet, Nutrient: "protien_g", Days: 30},
+			err:  ErrUnknownNutrient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{}
+			service := NewService(repo, nil, fakeGoals{}, fakeUsers{})
+
+			rule := tt.rule
+			if err := service.CreateRule("user-1", &rule); !errors.Is(err, tt.err) {
+				t.Fatalf("CreateRule() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if len(repo.created) > 0 {
+					t.Errorf("CreateRule() stored %+v", repo.created)
+				}
+				return
+			}
+			if rule.TimeZone != tt.timeZone {
+				t.Errorf("TimeZone = %q, want %q", rule.TimeZone, tt.timeZone)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package achievement
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+)
+
+// runContaining is the number of consecutive days in the sorted dates that
+// include day, or 0 when day is not among them.
+func runContaining(dates []db.Date, day db.Date) int {
+	for i, date := range dates {
+		if !date.Equal(day.Time) {
+			continue
+		}
+		start, end := i, i
+		for start > 0 && consecutive(dates[start-1], dates[start]) {
+			start--
+		}
+		for end < len(dates)-1 && consecutive(dates[end], dates[end+1]) {
+			end++
+		}
+		return end - start + 1
+	}
+	return 0
+}
+
+// currentRun is the streak of sorted dates ending today, or yesterday while
+// today has not counted yet.
+func currentRun(dates []db.Date, today db.Date) int {
+	if len(dates) == 0 {
+		return 0
+	}
+	last := dates[len(dates)-1]
+	if !last.Equal(today.Time) && !last.Equal(today.AddDate(0, 0, -1)) {
+		return 0
+	}
+	return runContaining(dates, last)
+}
+
+// longestRun is the longest streak in the sorted dates.
+func longestRun(dates []db.Date) int {
+	longest, run := 0, 0
+	for i := range dates {
+		if i > 0 && consecutive(dates[i-1], dates[i]) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// completedOn is the first of the sorted dates on which the streak, or in total
+// mode the number of dates, reached target.
+func completedOn(dates []db.Date, mode string, target int) (db.Date, bool) {
+	run := 0
+	for i, date := range dates {
+		switch {
+		case mode == ModeTotal:
+			run = i + 1
+		case i > 0 && consecutive(dates[i-1], date):
+			run++
+		default:
+			run = 1
+		}
+		if run >= target {
+			return date, true
+		}
+	}
+	return db.Date{}, false
+}
+
+// before returns the sorted dates earlier than day.
+func before(dates []db.Date, day db.Date) []db.Date {
+	for i, date := range dates {
+		if !date.Before(day.Time) {
+			return dates[:i]
+		}
+	}
+	return dates
+}
+
+func consecutive(a, b db.Date) bool {
+	return a.AddDate(0, 0, 1).Equal(b.Time)
+}
+
+// localDays are the distinct calendar days in location of the given times.
+func localDays(times []time.Time, location *time.Location) []db.Date {
+	seen := make(map[db.Date]bool)
+	var days []db.Date
+	for _, t := range times {
+		day := db.NewDate(t.In(location))
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	return days
+}
@@ -0,0 +1,134 @@
+package achievement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+)
+
+func date(day int) db.Date {
+	return db.NewDate(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day))
+}
+
+func dates(days ...int) []db.Date {
+	result := make([]db.Date, 0, len(days))
+	for _, day := range days {
+		result = append(result, date(day))
+	}
+	return result
+}
+
+func TestCurrentRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		dates []db.Date
+		today int
+		want  int
+	}{
+		{name: "no days", today: 5, want: 0},
+		{name: "streak ending today", dates: dates(1, 3, 4, 5), today: 5, want: 3},
+		{name: "today not counted yet", dates: dates(2, 3, 4), today: 5, want: 3},
+		{name: "broken yesterday", dates: dates(1, 2, 3), today: 5, want: 0},
+		{name: "single day today", dates: dates(5), today: 5, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := currentRun(tt.dates, date(tt.today)); got != tt.want {
+				t.Errorf("currentRun() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		dates []db.Date
+		want  int
+	}{
+		{name: "no days", want: 0},
+		{name: "one day", dates: dates(3), want: 1},
+		{name: "longest first", dates: dates(1, 2, 3, 5, 6), want: 3},
+		{name: "longest last", dates: dates(1, 3, 4, 5, 6), want: 4},
+		{name: "across a month end", dates: dates(-2, -1, 0, 1), want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestRun(tt.dates); got != tt.want {
+				t.Errorf("longestRun() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletedOn(t *testing.T) {
+	tests := []struct {
+		name   string
+		dates  []db.Date
+		mode   string
+		target int
+		want   int
+		done   bool
+	}{
+		{name: "streak reached", dates: dates(1, 3, 4, 5, 6), mode: ModeStreak, target: 3, want: 5, done: true},
+		{name: "streak not reached", dates: dates(1, 2, 4, 5), mode: ModeStreak, target: 3},
+		{name: "total reached", dates: dates(1, 3, 7, 9), mode: ModeTotal, target: 3, want: 7, done: true},
+		{name: "total not reached", dates: dates(1, 3), mode: ModeTotal, target: 3},
+		{name: "no days", mode: ModeStreak, target: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, done := completedOn(tt.dates, tt.mode, tt.target)
+			if done != tt.done {
+				t.Fatalf("completedOn() completed = %v, want %v", done, tt.done)
+			}
+			if done && !got.Equal(date(tt.want).Time) {
+				t.Errorf("completedOn() = %v, want %v", got, date(tt.want))
+			}
+		})
+	}
+}
+
+func TestBefore(t *testing.T) {
+	tests := []struct {
+		name  string
+		dates []db.Date
+		day   int
+		want  int
+	}{
+		{name: "drops today and later", dates: dates(1, 2, 3, 4), day: 3, want: 2},
+		{name: "all earlier", dates: dates(1, 2), day: 5, want: 2},
+		{name: "none earlier", dates: dates(5, 6), day: 5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := before(tt.dates, date(tt.day)); len(got) != tt.want {
+				t.Errorf("before() kept %d dates, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalDays(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	times := []time.Time{
+		time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 16, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	if got := localDays(times, time.UTC); len(got) != 1 || !got[0].Equal(date(0).Time) {
+		t.Errorf("localDays(UTC) = %v, want only 2026-03-01", got)
+	}
+	if got := localDays(times, tokyo); len(got) != 2 || !got[0].Equal(date(0).Time) || !got[1].Equal(date(1).Time) {
+		t.Errorf("localDays(Tokyo) = %v, want 2026-03-01 and 2026-03-02", got)
+	}
+}
@@ -0,0 +1,18 @@
+package activity
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/body"
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger, body body.Service, diary diary.Service) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, body, diary)
+	validator := validator.New()
+	activityLogger := logger.Named("ActivityHandler")
+
+	return NewHandler(service, validator, activityLogger)
+}
@@ -0,0 +1,420 @@
+package activity
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetTypes(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	query := r.URL.Query().Get("q")
+	category := r.URL.Query().Get("category")
+
+	types, total, err := h.Service.GetTypes(query, category, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving activity types")
+		h.Logger.Error("Error retrieving activity types", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     types,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(types),
+	}
+
+	h.Logger.Info("Retrieved activity types", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(types)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateType(w http.ResponseWriter, r *http.Request) {
+	var activityType Type
+	if err := json.NewDecoder(r.Body).Decode(&activityType); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(activityType); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateType(&activityType); err != nil {
+		h.writeTypeError(w, err, "", "Error creating activity type")
+		return
+	}
+
+	h.Logger.Info("Created new activity type", zap.String("id", activityType.ID), zap.String("code", activityType.Code))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(activityType); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetTypeByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing activity type ID")
+		h.Logger.Warn("Missing activity type ID in request")
+		return
+	}
+
+	activityType, err := h.Service.GetTypeByID(id)
+	if err != nil {
+		h.writeTypeError(w, err, id, "Error retrieving activity type")
+		return
+	}
+
+	h.Logger.Info("Retrieved activity type", zap.String("id", activityType.ID), zap.String("name", activityType.Name))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(activityType); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateType(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing activity type ID")
+		h.Logger.Warn("Missing activity type ID in request")
+		return
+	}
+
+	var updatedData Type
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateType(&updatedData); err != nil {
+		h.writeTypeError(w, err, id, "Error updating activity type")
+		return
+	}
+
+	h.Logger.Info("Updated activity type", zap.String("id", updatedData.ID), zap.String("name", updatedData.Name))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteType(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing activity type ID")
+		h.Logger.Warn("Missing activity type ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteType(id); err != nil {
+		h.writeTypeError(w, err, id, "Error deleting activity type")
+		return
+	}
+
+	h.Logger.Info("Deleted activity type", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	sessions, total, err := h.Service.GetSessions(userID, period, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving activity sessions")
+		h.Logger.Error("Error retrieving activity sessions", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     sessions,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(sessions),
+	}
+
+	h.Logger.Info("Retrieved activity sessions", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(sessions)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var session Session
+	if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateSession(userID, &session); err != nil {
+		h.writeSessionError(w, err, "", "Error creating activity session")
+		return
+	}
+
+	h.Logger.Info("Created activity session", zap.String("id", session.ID), zap.Float64("energy_kcal", session.EnergyKcal))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetSessionByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing activity session ID")
+		h.Logger.Warn("Missing activity session ID in request")
+		return
+	}
+
+	session, err := h.Service.GetSessionByID(userID, id)
+	if err != nil {
+		h.writeSessionError(w, err, id, "Error retrieving activity session")
+		return
+	}
+
+	h.Logger.Info("Retrieved activity session", zap.String("id", session.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing activity session ID")
+		h.Logger.Warn("Missing activity session ID in request")
+		return
+	}
+
+	var updatedData Session
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateSession(userID, &updatedData); err != nil {
+		h.writeSessionError(w, err, id, "Error updating activity session")
+		return
+	}
+
+	h.Logger.Info("Updated activity session", zap.String("id", updatedData.ID), zap.Float64("energy_kcal", updatedData.EnergyKcal))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing activity session ID")
+		h.Logger.Warn("Missing activity session ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteSession(userID, id); err != nil {
+		h.writeSessionError(w, err, id, "Error deleting activity session")
+		return
+	}
+
+	h.Logger.Info("Deleted activity session", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	date := chi.URLParam(r, "date")
+	parsed, err := db.ParseDate(date)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+		h.Logger.Warn("Invalid date", zap.String("date", date))
+		return
+	}
+
+	balance, err := h.Service.GetBalance(userID, parsed.In(location))
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating energy balance")
+		h.Logger.Error("Error calculating energy balance", zap.String("date", date), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Calculated energy balance", zap.String("date", date), zap.Float64("net_kcal", balance.NetKcal))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(balance); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeTypeError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Activity type not found")
+		h.Logger.Warn("Activity type not found", zap.String("id", id))
+	case ErrTypeExists:
+		errors.WriteHTTPError(w, http.StatusConflict, "Activity type code already exists")
+		h.Logger.Warn("Duplicate activity type code", zap.String("id", id))
+	case ErrTypeInUse:
+		errors.WriteHTTPError(w, http.StatusConflict, "Activity type is still in use")
+		h.Logger.Warn("Activity type is still in use", zap.String("id", id))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
+
+func (h *Handler) writeSessionError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Activity session not found")
+		h.Logger.Warn("Activity session not found", zap.String("id", id))
+	case ErrTypeNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Activity type not found")
+		h.Logger.Warn("Activity type not found", zap.String("id", id))
+	case ErrWeightRequired:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "No body weight logged, pass 'weight_kg' or log a weight first")
+		h.Logger.Warn("No body weight for activity session", zap.String("id", id))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
@@ -0,0 +1,62 @@
+package activity
+
+import "time"
+
+// Intensities record how hard a session felt. They do not change its MET:
+// Compendium codes are specific to an intensity already, so a harder effort is
+// a different activity type.
+const (
+	IntensityLight    = "light"
+	IntensityModerate = "moderate"
+	IntensityVigorous = "vigorous"
+)
+
+// Type is an activity with its metabolic equivalent (MET), e.g. from the
+// Compendium of Physical Activities. Code is the Compendium code where known.
+type Type struct {
+	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Code      string    `json:"code" gorm:"not null;uniqueIndex" validate:"required,min=1,max=20"`
+	Name      string    `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	Category  string    `json:"category" gorm:"not null" validate:"required,min=1,max=50"`
+	MET       float64   `json:"met" gorm:"column:met;not null" validate:"required,gte=0.9,lte=25"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Type) TableName() string {
+	return "activity_types"
+}
+
+// Session is a bout of an activity. WeightKg defaults to the latest logged body
+// weight; MET and EnergyKcal are computed when the session is saved.
+type Session struct {
+	ID             string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID         string    `json:"-" gorm:"type:uuid;not null"`
+	ActivityTypeID string    `json:"activity_type_id" gorm:"type:uuid;not null" validate:"required,uuid"`
+	StartedAt      time.Time `json:"started_at" gorm:"not null" validate:"required"`
+	DurationMin    float64   `json:"duration_min" gorm:"not null" validate:"required,gt=0,lte=1440"`
+	Intensity      string    `json:"intensity" gorm:"not null;default:'moderate'" validate:"omitempty,oneof=light moderate vigorous"`
+	WeightKg       *float64  `json:"weight_kg" gorm:"not null" validate:"omitempty,gt=0,lte=700"`
+	MET            float64   `json:"met" gorm:"column:met;not null"`
+	EnergyKcal     float64   `json:"energy_kcal" gorm:"not null"`
+	Notes          string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	ActivityType   *Type     `json:"activity_type,omitempty" validate:"-"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Session) TableName() string {
+	return "activity_sessions"
+}
+
+// Balance is the energy balance of a day: intake logged in the diary minus the
+// energy estimated for activity sessions started that day.
+type Balance struct {
+	Date            string  `json:"date"`
+	TimeZone        string  `json:"time_zone"`
+	IntakeKcal      float64 `json:"intake_kcal"`
+	ExpenditureKcal float64 `json:"expenditure_kcal"`
+	NetKcal         float64 `json:"net_kcal"`
+	Sessions        int     `json:"sessions"`
+	ActiveMinutes   float64 `json:"active_minutes"`
+}
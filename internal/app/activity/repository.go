@@ -0,0 +1,139 @@
+package activity
+
+import (
+	"strings"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Expenditure is the summed activity of a range.
+type Expenditure struct {
+	Sessions      int
+	ActiveMinutes float64
+	EnergyKcal    float64
+}
+
+type Repository interface {
+	GetTypes(query, category string, limit, offset int) ([]Type, int64, error)
+	GetTypeByID(id string) (*Type, error)
+	CreateType(activityType *Type) error
+	UpdateType(activityType *Type) error
+	DeleteType(id string) error
+
+	GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error)
+	GetSessionByID(userID, id string) (*Session, error)
+	CreateSession(session *Session) error
+	UpdateSession(session *Session) error
+	DeleteSession(userID, id string) error
+
+	GetExpenditure(userID string, period reporting.Range) (*Expenditure, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetTypes(query, category string, limit, offset int) ([]Type, int64, error) {
+	var types []Type
+	var total int64
+
+	q := r.db.Model(&Type{})
+	if term := strings.TrimSpace(query); term != "" {
+		q = q.Where(`name ILIKE ? ESCAPE '\'`, db.ContainsPattern(term))
+	}
+	if category != "" {
+		q = q.Where("category = ?", category)
+	}
+
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := q.Order("category, name").Limit(limit).Offset(offset).Find(&types).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return types, total, nil
+}
+
+func (r *repositoryImpl) GetTypeByID(id string) (*Type, error) {
+	var activityType Type
+	if err := r.db.First(&activityType, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &activityType, nil
+}
+
+func (r *repositoryImpl) CreateType(activityType *Type) error {
+	return r.db.Create(activityType).Error
+}
+
+func (r *repositoryImpl) UpdateType(activityType *Type) error {
+	return r.db.Save(activityType).Error
+}
+
+func (r *repositoryImpl) DeleteType(id string) error {
+	return r.db.Delete(&Type{}, "id = ?", id).Error
+}
+
+func (r *repositoryImpl) GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error) {
+	var sessions []Session
+	var total int64
+
+	query := r.db.Model(&Session{}).Where("user_id = ?", userID)
+	if !period.From.IsZero() {
+		query = query.Where("started_at >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where("started_at < ?", period.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("ActivityType").Order("started_at DESC").Limit(limit).Offset(offset).Find(&sessions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return sessions, total, nil
+}
+
+func (r *repositoryImpl) GetSessionByID(userID, id string) (*Session, error) {
+	var session Session
+	if err := r.db.Preload("ActivityType").First(&session, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *repositoryImpl) CreateSession(session *Session) error {
+	return r.db.Omit(clause.Associations).Create(session).Error
+}
+
+func (r *repositoryImpl) UpdateSession(session *Session) error {
+	return r.db.Omit(clause.Associations).Save(session).Error
+}
+
+func (r *repositoryImpl) DeleteSession(userID, id string) error {
+	return r.db.Delete(&Session{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetExpenditure(userID string, period reporting.Range) (*Expenditure, error) {
+	var expenditure Expenditure
+	err := r.db.Model(&Session{}).
+		Select("COUNT(*) AS sessions, COALESCE(SUM(duration_min), 0) AS active_minutes, COALESCE(SUM(energy_kcal), 0) AS energy_kcal").
+		Where("user_id = ? AND started_at >= ? AND started_at < ?", userID, period.From, period.To).
+		Scan(&expenditure).Error
+	if err != nil {
+		return nil, err
+	}
+	return &expenditure, nil
+}
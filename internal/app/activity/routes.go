@@ -0,0 +1,23 @@
+package activity
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/types", h.GetTypes)
+	r.Post("/types", h.CreateType)
+	r.Get("/types/{id}", h.GetTypeByID)
+	r.Put("/types/{id}", h.UpdateType)
+	r.Delete("/types/{id}", h.DeleteType)
+
+	r.Get("/sessions", h.GetSessions)
+	r.Post("/sessions", h.CreateSession)
+	r.Get("/sessions/{id}", h.GetSessionByID)
+	r.Put("/sessions/{id}", h.UpdateSession)
+	r.Delete("/sessions/{id}", h.DeleteSession)
+
+	r.Get("/days/{date}/balance", h.GetBalance)
+
+	return r
+}
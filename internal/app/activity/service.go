@@ -0,0 +1,187 @@
+package activity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/body"
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTypeNotFound   = errors.New("activity type not found")
+	ErrTypeExists     = errors.New("activity type code already exists")
+	ErrTypeInUse      = errors.New("activity type is still in use")
+	ErrWeightRequired = errors.New("no body weight to estimate energy from")
+)
+
+type Service interface {
+	GetTypes(query, category string, limit, offset int) ([]Type, int64, error)
+	GetTypeByID(id string) (*Type, error)
+	CreateType(activityType *Type) error
+	UpdateType(activityType *Type) error
+	DeleteType(id string) error
+
+	GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error)
+	GetSessionByID(userID, id string) (*Session, error)
+	CreateSession(userID string, session *Session) error
+	UpdateSession(userID string, session *Session) error
+	DeleteSession(userID, id string) error
+
+	// GetBalance returns the energy balance of the day starting at the given local midnight.
+	GetBalance(userID string, day time.Time) (*Balance, error)
+}
+
+type serviceImpl struct {
+	repo  Repository
+	body  body.Service
+	diary diary.Service
+}
+
+func NewService(repo Repository, body body.Service, diary diary.Service) Service {
+	return &serviceImpl{repo: repo, body: body, diary: diary}
+}
+
+func (s *serviceImpl) GetTypes(query, category string, limit, offset int) ([]Type, int64, error) {
+	return s.repo.GetTypes(query, category, limit, offset)
+}
+
+func (s *serviceImpl) GetTypeByID(id string) (*Type, error) {
+	return s.repo.GetTypeByID(id)
+}
+
+func (s *serviceImpl) CreateType(activityType *Type) error {
+	if err := s.repo.CreateType(activityType); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrTypeExists
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateType changes a type. Existing sessions keep the MET and energy they were saved with.
+func (s *serviceImpl) UpdateType(activityType *Type) error {
+	existingType, err := s.repo.GetTypeByID(activityType.ID)
+	if err != nil {
+		return err
+	}
+
+	activityType.CreatedAt = existingType.CreatedAt
+	if err := s.repo.UpdateType(activityType); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrTypeExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) DeleteType(id string) error {
+	if _, err := s.repo.GetTypeByID(id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteType(id); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrTypeInUse
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error) {
+	return s.repo.GetSessions(userID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetSessionByID(userID, id string) (*Session, error) {
+	return s.repo.GetSessionByID(userID, id)
+}
+
+func (s *serviceImpl) CreateSession(userID string, session *Session) error {
+	session.UserID = userID
+	if err := s.estimate(session); err != nil {
+		return err
+	}
+
+	return s.repo.CreateSession(session)
+}
+
+func (s *serviceImpl) UpdateSession(userID string, session *Session) error {
+	existingSession, err := s.repo.GetSessionByID(userID, session.ID)
+	if err != nil {
+		return err
+	}
+
+	session.UserID = userID
+	if err := s.estimate(session); err != nil {
+		return err
+	}
+
+	session.CreatedAt = existingSession.CreatedAt
+	return s.repo.UpdateSession(session)
+}
+
+func (s *serviceImpl) DeleteSession(userID, id string) error {
+	if _, err := s.repo.GetSessionByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteSession(userID, id)
+}
+
+func (s *serviceImpl) GetBalance(userID string, day time.Time) (*Balance, error) {
+	summary, err := s.diary.GetDaySummary(userID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	expenditure, err := s.repo.GetExpenditure(userID, reporting.Range{From: day, To: day.AddDate(0, 0, 1)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Balance{
+		Date:            summary.Date,
+		TimeZone:        summary.TimeZone,
+		IntakeKcal:      summary.Totals.EnergyKcal,
+		ExpenditureKcal: reporting.Round(expenditure.EnergyKcal, 1),
+		NetKcal:         reporting.Round(summary.Totals.EnergyKcal-expenditure.EnergyKcal, 1),
+		Sessions:        expenditure.Sessions,
+		ActiveMinutes:   expenditure.ActiveMinutes,
+	}, nil
+}
+
+// estimate sets the session's MET and energy: kcal = MET × weight in kg × hours.
+func (s *serviceImpl) estimate(session *Session) error {
+	activityType, err := s.repo.GetTypeByID(session.ActivityTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTypeNotFound
+		}
+		return err
+	}
+
+	if session.Intensity == "" {
+		session.Intensity = IntensityModerate
+	}
+
+	if session.WeightKg == nil {
+		weight, err := s.body.GetLatestWeight(session.UserID, session.StartedAt)
+		if err != nil {
+			if errors.Is(err, body.ErrNoWeight) {
+				return ErrWeightRequired
+			}
+			return err
+		}
+		session.WeightKg = &weight
+	}
+
+	session.MET = activityType.MET
+	energy := session.MET * *session.WeightKg * session.DurationMin / 60
+	session.EnergyKcal = reporting.Round(energy, 1)
+	session.ActivityType = activityType
+	return nil
+}
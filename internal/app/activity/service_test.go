@@ -0,0 +1,86 @@
+package activity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/body"
+	"gorm.io/gorm"
+)
+
+// fakeRepository serves activity types and accepts every session.
+type fakeRepository struct {
+	Repository
+	types map[string]Type
+}
+
+func (r *fakeRepository) GetTypeByID(id string) (*Type, error) {
+	activityType, ok := r.types[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &activityType, nil
+}
+
+func (r *fakeRepository) CreateSession(session *Session) error {
+	return nil
+}
+
+// fakeBody returns weight as the latest weight, or ErrNoWeight if it is zero.
+type fakeBody struct {
+	body.Service
+	weight float64
+}
+
+func (b fakeBody) GetLatestWeight(userID string, at time.Time) (float64, error) {
+	if b.weight == 0 {
+		return 0, body.ErrNoWeight
+	}
+	return b.weight, nil
+}
+
+func TestCreateSessionEstimatesEnergy(t *testing.T) {
+	repo := &fakeRepository{types: map[string]Type{
+		"running": {ID: "running", MET: 9.8},
+		"cycling": {ID: "cycling", MET: 7.5},
+	}}
+	weight := 70.0
+
+	tests := []struct {
+		name       string
+		session    Session
+		latest     float64
+		wantWeight float64
+		wantKcal   float64
+		wantErr    error
+	}{
+		{"given weight", Session{ActivityTypeID: "running", DurationMin: 30, WeightKg: &weight}, 0, 70, 343, nil},
+		{"latest weight", Session{ActivityTypeID: "cycling", DurationMin: 45}, 82.5, 82.5, 464.1, nil},
+		{"intensity keeps the MET", Session{ActivityTypeID: "running", DurationMin: 30, WeightKg: &weight, Intensity: IntensityVigorous}, 0, 70, 343, nil},
+		{"no weight logged", Session{ActivityTypeID: "cycling", DurationMin: 45}, 0, 0, 0, ErrWeightRequired},
+		{"unknown type", Session{ActivityTypeID: "rowing", DurationMin: 20, WeightKg: &weight}, 0, 0, 0, ErrTypeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(repo, fakeBody{weight: tt.latest}, nil)
+			session := tt.session
+
+			err := service.CreateSession("user-1", &session)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateSession() = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if *session.WeightKg != tt.wantWeight || session.EnergyKcal != tt.wantKcal {
+				t.Errorf("CreateSession() = %v kg, %v kcal, want %v kg, %v kcal",
+					*session.WeightKg, session.EnergyKcal, tt.wantWeight, tt.wantKcal)
+			}
+			if session.MET != repo.types[session.ActivityTypeID].MET {
+				t.Errorf("CreateSession() MET = %v, want %v", session.MET, repo.types[session.ActivityTypeID].MET)
+			}
+		})
+	}
+}
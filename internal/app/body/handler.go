@@ -0,0 +1,292 @@
+package body
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultSeriesDays is the length of a series requested without "from".
+const defaultSeriesDays = 90
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	measurements, total, err := h.Service.GetAll(userID, period, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving measurements")
+		h.Logger.Error("Error retrieving measurements", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     measurements,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(measurements),
+	}
+
+	h.Logger.Info("Retrieved measurements", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(measurements)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var measurement Measurement
+	if err := json.NewDecoder(r.Body).Decode(&measurement); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(measurement); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(userID, &measurement); err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating measurement")
+		h.Logger.Error("Error creating measurement", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Created new measurement", zap.String("id", measurement.ID), zap.Time("measured_at", measurement.MeasuredAt))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(measurement); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing measurement ID")
+		h.Logger.Warn("Missing measurement ID in request")
+		return
+	}
+
+	measurement, err := h.Service.GetByID(userID, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Measurement not found")
+			h.Logger.Warn("Measurement not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving measurement")
+		h.Logger.Error("Error retrieving measurement", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved measurement", zap.String("id", measurement.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(measurement); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing measurement ID")
+		h.Logger.Warn("Missing measurement ID in request")
+		return
+	}
+
+	var updatedData Measurement
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(userID, &updatedData); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Measurement not found")
+			h.Logger.Warn("Measurement not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating measurement")
+		h.Logger.Error("Error updating measurement", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Updated measurement", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing measurement ID")
+		h.Logger.Warn("Missing measurement ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(userID, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Measurement not found")
+			h.Logger.Warn("Measurement not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting measurement")
+		h.Logger.Error("Error deleting measurement", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Deleted measurement", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	query := SeriesQuery{
+		Metric:   r.URL.Query().Get("metric"),
+		Interval: r.URL.Query().Get("interval"),
+	}
+	if query.Metric == "" {
+		query.Metric = "weight"
+	}
+
+	switch query.Interval {
+	case "":
+		query.Interval = IntervalDay
+	case IntervalDay, IntervalWeek:
+	default:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'interval' parameter, expected 'day' or 'week'")
+		h.Logger.Warn("Invalid 'interval' parameter", zap.String("interval", query.Interval))
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+	query.Location = location
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+	if period.To.IsZero() {
+		now := time.Now().In(location)
+		period.To = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, location)
+	}
+	if period.From.IsZero() {
+		period.From = period.To.AddDate(0, 0, -defaultSeriesDays)
+	}
+	query.Range = period
+
+	series, err := h.Service.GetSeries(userID, query)
+	if err != nil {
+		if err == ErrUnknownMetric {
+			errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("Unknown metric '%s'", query.Metric))
+			h.Logger.Warn("Unknown metric", zap.String("metric", query.Metric))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving series")
+		h.Logger.Error("Error retrieving series", zap.String("metric", query.Metric), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved series", zap.String("metric", query.Metric), zap.String("interval", query.Interval), zap.Int("points", len(series.Points)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(series); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
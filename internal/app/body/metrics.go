@@ -0,0 +1,99 @@
+package body
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// Downsampling intervals.
+const (
+	IntervalDay  = "day"
+	IntervalWeek = "week"
+)
+
+// SmoothingFactor is the weight of a new daily value in the exponentially
+// weighted moving average; for gaps of several days it is compounded per day.
+const SmoothingFactor = 0.1
+
+// warmupDays of data before the requested range seed the trend.
+const warmupDays = 60
+
+// RateWindowDays is the trailing window the weekly rate of change is fitted over.
+const RateWindowDays = 14
+
+var ErrUnknownMetric = errors.New("unknown metric")
+
+type metric struct {
+	column string
+	unit   string
+}
+
+var metrics = map[string]metric{
+	"weight":   {"weight_kg", "kg"},
+	"body_fat": {"body_fat_pct", "%"},
+	"waist":    {"waist_cm", "cm"},
+	"hip":      {"hip_cm", "cm"},
+	"chest":    {"chest_cm", "cm"},
+}
+
+// smooth fills in the trend of daily buckets, weighting each day's average with
+// SmoothingFactor compounded over the days since the previous bucket.
+func smooth(days []Bucket) {
+	for i := range days {
+		if i == 0 {
+			days[i].Trend = days[i].Average
+			continue
+		}
+
+		gap := days[i].Start.Sub(days[i-1].Start).Hours() / 24
+		alpha := 1 - math.Pow(1-SmoothingFactor, math.Max(gap, 1))
+		days[i].Trend = days[i-1].Trend + alpha*(days[i].Average-days[i-1].Trend)
+	}
+}
+
+// weeklyRate fits a least-squares line through the trend of the daily buckets
+// in the last RateWindowDays and returns its slope per week.
+func weeklyRate(days []Bucket) *float64 {
+	if len(days) == 0 {
+		return nil
+	}
+
+	last := days[len(days)-1].Start
+	windowStart := last.AddDate(0, 0, -RateWindowDays)
+
+	var n, sumX, sumY, sumXY, sumXX float64
+	for _, day := range days {
+		if day.Start.Before(windowStart) {
+			continue
+		}
+		x := day.Start.Sub(last).Hours() / 24
+		n++
+		sumX += x
+		sumY += day.Trend
+		sumXY += x * day.Trend
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if n < 2 || denominator == 0 {
+		return nil
+	}
+
+	rate := reporting.Round((n*sumXY-sumX*sumY)/denominator*7, 3)
+	return &rate
+}
+
+// trendAt returns the trend of the last daily bucket starting before end.
+func trendAt(days []Bucket, end time.Time) (float64, bool) {
+	trend, found := 0.0, false
+	for _, day := range days {
+		if !day.Start.Before(end) {
+			break
+		}
+		trend, found = day.Trend, true
+	}
+	return trend, found
+}
@@ -0,0 +1,103 @@
+package body
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func day(n int) time.Time {
+	return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n)
+}
+
+func TestSmooth(t *testing.T) {
+	tests := []struct {
+		name string
+		days []Bucket
+		want []float64
+	}{
+		{name: "empty"},
+		{name: "first day seeds the trend", days: []Bucket{{Start: day(0), Average: 80}}, want: []float64{80}},
+		{
+			name: "consecutive days",
+			days: []Bucket{{Start: day(0), Average: 80}, {Start: day(1), Average: 81}, {Start: day(2), Average: 81}},
+			want: []float64{80, 80.1, 80.19},
+		},
+		{
+			name: "gap compounds the factor",
+			days: []Bucket{{Start: day(0), Average: 80}, {Start: day(3), Average: 90}},
+			want: []float64{80, 82.71},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			smooth(tt.days)
+			for i, want := range tt.want {
+				if math.Abs(tt.days[i].Trend-want) > 1e-9 {
+					t.Errorf("Trend[%d] = %v, want %v", i, tt.days[i].Trend, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWeeklyRate(t *testing.T) {
+	linear := func(from, to int, perDay float64) []Bucket {
+		var days []Bucket
+		for n := from; n <= to; n++ {
+			days = append(days, Bucket{Start: day(n), Trend: 80 + perDay*float64(n)})
+		}
+		return days
+	}
+
+	tests := []struct {
+		name string
+		days []Bucket
+		want *float64
+	}{
+		{name: "no days"},
+		{name: "one day", days: linear(0, 0, 0.1)},
+		{name: "gaining", days: linear(0, 9, 0.1), want: ptr(0.7)},
+		{name: "losing", days: linear(0, 20, -0.05), want: ptr(-0.35)},
+		{
+			name: "days before the window are ignored",
+			days: append([]Bucket{{Start: day(-30), Trend: 120}}, linear(0, 9, 0.1)...),
+			want: ptr(0.7),
+		},
+		{
+			name: "only one day in the window",
+			days: []Bucket{{Start: day(0), Trend: 80}, {Start: day(30), Trend: 82}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := weeklyRate(tt.days)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("weeklyRate() = %v, want nil", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("weeklyRate() = %v, want %v", got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestTrendAt(t *testing.T) {
+	days := []Bucket{{Start: day(0), Trend: 80}, {Start: day(2), Trend: 79}}
+
+	if _, found := trendAt(days, day(0)); found {
+		t.Error("trendAt() before the first day found a trend")
+	}
+	if trend, found := trendAt(days, day(2)); !found || trend != 80 {
+		t.Errorf("trendAt(day 2) = %v, %v, want 80, true", trend, found)
+	}
+	if trend, found := trendAt(days, day(5)); !found || trend != 79 {
+		t.Errorf("trendAt(day 5) = %v, %v, want 79, true", trend, found)
+	}
+}
+
+func ptr(value float64) *float64 {
+	return &value
+}
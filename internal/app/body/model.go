@@ -0,0 +1,61 @@
+package body
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// Measurement is a set of body measurements taken at one time. Every value is
+// optional, but at least one must be present.
+type Measurement struct {
+	ID         string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string    `json:"-" gorm:"type:uuid;not null"`
+	MeasuredAt time.Time `json:"measured_at" gorm:"not null" validate:"required"`
+	WeightKg   *float64  `json:"weight_kg" validate:"required_without_all=BodyFatPct WaistCm HipCm ChestCm,omitempty,gt=0,lte=700"`
+	BodyFatPct *float64  `json:"body_fat_pct" validate:"omitempty,gt=0,lt=100"`
+	WaistCm    *float64  `json:"waist_cm" validate:"omitempty,gt=0,lte=500"`
+	HipCm      *float64  `json:"hip_cm" validate:"omitempty,gt=0,lte=500"`
+	ChestCm    *float64  `json:"chest_cm" validate:"omitempty,gt=0,lte=500"`
+	Notes      string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Measurement) TableName() string {
+	return "body_measurements"
+}
+
+// Bucket aggregates the values of one metric over a day or a week.
+type Bucket struct {
+	Start   time.Time `json:"start"`
+	Count   int       `json:"count"`
+	Average float64   `json:"average"`
+	Min     float64   `json:"min"`
+	Max     float64   `json:"max"`
+	// Trend is the exponentially smoothed value at the end of the bucket.
+	Trend float64 `json:"trend"`
+}
+
+// Series is a downsampled metric with its smoothed trend.
+type Series struct {
+	Metric   string    `json:"metric"`
+	Unit     string    `json:"unit"`
+	Interval string    `json:"interval"`
+	TimeZone string    `json:"time_zone"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Points   []Bucket  `json:"points"`
+	// Trend is the latest smoothed value, nil without data.
+	Trend *float64 `json:"trend"`
+	// WeeklyRate is the change of the trend per week over the last RateWindowDays.
+	WeeklyRate *float64 `json:"weekly_rate"`
+}
+
+// SeriesQuery selects a metric series.
+type SeriesQuery struct {
+	Metric   string
+	Interval string
+	reporting.Range
+	Location *time.Location
+}
@@ -0,0 +1,115 @@
+package body
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// bucketsSQL aggregates a metric column per day or week in a time zone. The
+// column is taken from the metrics whitelist, never from user input.
+const bucketsSQL = `
+SELECT date_trunc(?, measured_at AT TIME ZONE ?) AS start,
+       COUNT(%[1]s)                              AS count,
+       AVG(%[1]s)                                AS average,
+       MIN(%[1]s)                                AS min,
+       MAX(%[1]s)                                AS max
+FROM body_measurements
+WHERE user_id = ?
+  AND %[1]s IS NOT NULL
+  AND measured_at >= ?
+  AND measured_at < ?
+GROUP BY 1
+ORDER BY 1`
+
+type Repository interface {
+	GetAll(userID string, period reporting.Range, limit, offset int) ([]Measurement, int64, error)
+	GetByID(userID, id string) (*Measurement, error)
+	Create(measurement *Measurement) error
+	Update(measurement *Measurement) error
+	Delete(userID, id string) error
+	GetBuckets(userID, column, interval string, location *time.Location, period reporting.Range) ([]Bucket, error)
+	GetLatestWeight(userID string, at time.Time) (*Measurement, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(userID string, period reporting.Range, limit, offset int) ([]Measurement, int64, error) {
+	var measurements []Measurement
+	var total int64
+
+	query := r.db.Model(&Measurement{}).Where("user_id = ?", userID)
+	if !period.From.IsZero() {
+		query = query.Where("measured_at >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where("measured_at < ?", period.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("measured_at DESC").Limit(limit).Offset(offset).Find(&measurements).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return measurements, total, nil
+}
+
+func (r *repositoryImpl) GetByID(userID, id string) (*Measurement, error) {
+	var measurement Measurement
+	if err := r.db.First(&measurement, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &measurement, nil
+}
+
+func (r *repositoryImpl) Create(measurement *Measurement) error {
+	return r.db.Create(measurement).Error
+}
+
+func (r *repositoryImpl) Update(measurement *Measurement) error {
+	return r.db.Save(measurement).Error
+}
+
+func (r *repositoryImpl) Delete(userID, id string) error {
+	return r.db.Delete(&Measurement{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+// GetLatestWeight returns the last measurement with a weight taken at or before the time.
+func (r *repositoryImpl) GetLatestWeight(userID string, at time.Time) (*Measurement, error) {
+	var measurement Measurement
+	err := r.db.Where("user_id = ? AND weight_kg IS NOT NULL AND measured_at <= ?", userID, at).
+		Order("measured_at DESC").
+		First(&measurement).Error
+	if err != nil {
+		return nil, err
+	}
+	return &measurement, nil
+}
+
+// GetBuckets returns the buckets in the range, their Start being local midnight
+// of the day or of the week's Monday in the location.
+func (r *repositoryImpl) GetBuckets(userID, column, interval string, location *time.Location, period reporting.Range) ([]Bucket, error) {
+	var buckets []Bucket
+	err := r.db.Raw(fmt.Sprintf(bucketsSQL, column), interval, location.String(), userID, period.From, period.To).Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range buckets {
+		start := buckets[i].Start
+		buckets[i].Start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+	}
+	return buckets, nil
+}
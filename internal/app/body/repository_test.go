@@ -0,0 +1,55 @@
+package body
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db/dbtest"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+func TestRepositoryScopesToUser(t *testing.T) {
+	database, recorder := dbtest.Open(t)
+	repo := NewRepository(database)
+	period := reporting.Range{From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "GetByID", call: func() error {
+			_, err := repo.GetByID("user-1", "measurement-1")
+			return err
+		}},
+		{name: "Delete", call: func() error { return repo.Delete("user-1", "measurement-1") }},
+		{name: "GetAll", call: func() error {
+			_, _, err := repo.GetAll("user-1", period, 10, 0)
+			return err
+		}},
+		{name: "GetLatestWeight", call: func() error {
+			_, err := repo.GetLatestWeight("user-1", period.To)
+			return err
+		}},
+		{name: "GetBuckets", call: func() error {
+			_, err := repo.GetBuckets("user-1", "weight_kg", "day", time.UTC, period)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Statements = nil
+			// A dry run finds no rows, so only the statements are checked.
+			_ = tt.call()
+			if len(recorder.Statements) == 0 {
+				t.Fatal("no statement sent")
+			}
+			for _, statement := range recorder.Statements {
+				if !strings.Contains(statement, "user_id = 'user-1'") {
+					t.Errorf("statement not scoped to the user: %s", statement)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package body
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/measurements", h.GetAll)
+	r.Post("/measurements", h.Create)
+	r.Get("/measurements/{id}", h.GetByID)
+	r.Put("/measurements/{id}", h.Update)
+	r.Delete("/measurements/{id}", h.Delete)
+
+	r.Get("/series", h.GetSeries)
+
+	return r
+}
@@ -0,0 +1,137 @@
+package body
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+var ErrNoWeight = errors.New("no body weight logged")
+
+type Service interface {
+	GetAll(userID string, period reporting.Range, limit, offset int) ([]Measurement, int64, error)
+	GetByID(userID, id string) (*Measurement, error)
+	Create(userID string, measurement *Measurement) error
+	Update(userID string, measurement *Measurement) error
+	Delete(userID, id string) error
+	GetSeries(userID string, query SeriesQuery) (*Series, error)
+
+	// GetLatestWeight returns the last weight in kg the user logged at or before the time.
+	GetLatestWeight(userID string, at time.Time) (float64, error)
+}
+
+type serviceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetAll(userID string, period reporting.Range, limit, offset int) ([]Measurement, int64, error) {
+	return s.repo.GetAll(userID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(userID, id string) (*Measurement, error) {
+	return s.repo.GetByID(userID, id)
+}
+
+func (s *serviceImpl) Create(userID string, measurement *Measurement) error {
+	measurement.UserID = userID
+	return s.repo.Create(measurement)
+}
+
+func (s *serviceImpl) Update(userID string, measurement *Measurement) error {
+	existingMeasurement, err := s.repo.GetByID(userID, measurement.ID)
+	if err != nil {
+		return err
+	}
+
+	measurement.UserID = userID
+	measurement.CreatedAt = existingMeasurement.CreatedAt
+	return s.repo.Update(measurement)
+}
+
+func (s *serviceImpl) Delete(userID, id string) error {
+	if _, err := s.repo.GetByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(userID, id)
+}
+
+// GetSeries downsamples a metric and smooths it. The trend is computed on daily
+// averages, including warmupDays before the range so it starts settled.
+func (s *serviceImpl) GetSeries(userID string, query SeriesQuery) (*Series, error) {
+	m, ok := metrics[query.Metric]
+	if !ok {
+		return nil, ErrUnknownMetric
+	}
+
+	days, err := s.repo.GetBuckets(userID, m.column, IntervalDay, query.Location, reporting.Range{
+		From: query.From.AddDate(0, 0, -warmupDays),
+		To:   query.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	smooth(days)
+
+	series := &Series{
+		Metric:     query.Metric,
+		Unit:       m.unit,
+		Interval:   query.Interval,
+		TimeZone:   query.Location.String(),
+		From:       query.From,
+		To:         query.To,
+		Points:     []Bucket{},
+		WeeklyRate: weeklyRate(days),
+	}
+	if len(days) > 0 {
+		trend := reporting.Round(days[len(days)-1].Trend, 2)
+		series.Trend = &trend
+	}
+
+	var points []Bucket
+	if query.Interval == IntervalWeek {
+		points, err = s.repo.GetBuckets(userID, m.column, IntervalWeek, query.Location, query.Range)
+		if err != nil {
+			return nil, err
+		}
+		for i := range points {
+			points[i].Trend, _ = trendAt(days, points[i].Start.AddDate(0, 0, 7))
+		}
+	} else {
+		for _, day := range days {
+			if !day.Start.Before(startOfDay(query.From, query.Location)) {
+				points = append(points, day)
+			}
+		}
+	}
+
+	for _, point := range points {
+		point.Average = reporting.Round(point.Average, 2)
+		point.Trend = reporting.Round(point.Trend, 2)
+		series.Points = append(series.Points, point)
+	}
+
+	return series, nil
+}
+
+func (s *serviceImpl) GetLatestWeight(userID string, at time.Time) (float64, error) {
+	measurement, err := s.repo.GetLatestWeight(userID, at)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrNoWeight
+		}
+		return 0, err
+	}
+	return *measurement.WeightKg, nil
+}
+
+func startOfDay(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
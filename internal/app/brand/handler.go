@@ -0,0 +1,392 @@
+package brand
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	manufacturerID := r.URL.Query().Get("manufacturer_id")
+	if manufacturerID != "" {
+		if err := h.Validator.Var(manufacturerID, "uuid"); err != nil {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'manufacturer_id' parameter")
+			h.Logger.Warn("Invalid 'manufacturer_id' parameter", zap.String("manufacturer_id", manufacturerID))
+			return
+		}
+	}
+
+	brands, total, err := h.Service.GetAll(manufacturerID, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving brands")
+		h.Logger.Error("Error retrieving brands", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     brands,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(brands),
+	}
+
+	h.Logger.Info("Retrieved brands", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(brands)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var brand Brand
+	if err := json.NewDecoder(r.Body).Decode(&brand); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(brand); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(&brand); err != nil {
+		switch err {
+		case ErrManufacturerNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Manufacturer not found")
+			h.Logger.Warn("Manufacturer not found", zap.String("manufacturer_id", brand.ManufacturerID))
+		case gorm.ErrDuplicatedKey:
+			errors.WriteHTTPError(w, http.StatusConflict, "Brand already exists for this manufacturer")
+			h.Logger.Warn("Duplicate brand", zap.String("name", brand.Name), zap.String("manufacturer_id", brand.ManufacturerID))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating brand")
+			h.Logger.Error("Error creating brand", zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Created new brand", zap.String("id", brand.ID), zap.String("name", brand.Name))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(brand); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing brand ID")
+		h.Logger.Warn("Missing brand ID in request")
+		return
+	}
+
+	brand, err := h.Service.GetByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Brand not found")
+			h.Logger.Warn("Brand not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving brand")
+		h.Logger.Error("Error retrieving brand", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved brand", zap.String("id", brand.ID), zap.String("name", brand.Name))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(brand); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing brand ID")
+		h.Logger.Warn("Missing brand ID in request")
+		return
+	}
+
+	var updatedData Brand
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(&updatedData); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Brand not found")
+			h.Logger.Warn("Brand not found", zap.String("id", id))
+		case ErrManufacturerNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Manufacturer not found")
+			h.Logger.Warn("Manufacturer not found", zap.String("manufacturer_id", updatedData.ManufacturerID))
+		case gorm.ErrDuplicatedKey:
+			errors.WriteHTTPError(w, http.StatusConflict, "Brand already exists for this manufacturer")
+			h.Logger.Warn("Duplicate brand", zap.String("name", updatedData.Name), zap.String("manufacturer_id", updatedData.ManufacturerID))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating brand")
+			h.Logger.Error("Error updating brand", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Updated brand", zap.String("id", updatedData.ID), zap.String("name", updatedData.Name))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing brand ID")
+		h.Logger.Warn("Missing brand ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(id); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Brand not found")
+			h.Logger.Warn("Brand not found", zap.String("id", id))
+		case ErrBrandInUse:
+			errors.WriteHTTPError(w, http.StatusConflict, "Brand is still referenced by foods")
+			h.Logger.Warn("Brand is still referenced by foods", zap.String("id", id))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting brand")
+			h.Logger.Error("Error deleting brand", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Deleted brand", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetManufacturers(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	manufacturers, total, err := h.Service.GetManufacturers(limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving manufacturers")
+		h.Logger.Error("Error retrieving manufacturers", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     manufacturers,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(manufacturers),
+	}
+
+	h.Logger.Info("Retrieved manufacturers", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(manufacturers)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateManufacturer(w http.ResponseWriter, r *http.Request) {
+	var manufacturer Manufacturer
+	if err := json.NewDecoder(r.Body).Decode(&manufacturer); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(manufacturer); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateManufacturer(&manufacturer); err != nil {
+		if err == gorm.ErrDuplicatedKey {
+			errors.WriteHTTPError(w, http.StatusConflict, "Manufacturer already exists")
+			h.Logger.Warn("Duplicate manufacturer", zap.String("name", manufacturer.Name))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating manufacturer")
+		h.Logger.Error("Error creating manufacturer", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Created new manufacturer", zap.String("id", manufacturer.ID), zap.String("name", manufacturer.Name))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(manufacturer); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetManufacturerByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing manufacturer ID")
+		h.Logger.Warn("Missing manufacturer ID in request")
+		return
+	}
+
+	manufacturer, err := h.Service.GetManufacturerByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Manufacturer not found")
+			h.Logger.Warn("Manufacturer not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving manufacturer")
+		h.Logger.Error("Error retrieving manufacturer", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved manufacturer", zap.String("id", manufacturer.ID), zap.String("name", manufacturer.Name))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(manufacturer); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateManufacturer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing manufacturer ID")
+		h.Logger.Warn("Missing manufacturer ID in request")
+		return
+	}
+
+	var updatedData Manufacturer
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateManufacturer(&updatedData); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Manufacturer not found")
+			h.Logger.Warn("Manufacturer not found", zap.String("id", id))
+		case gorm.ErrDuplicatedKey:
+			errors.WriteHTTPError(w, http.StatusConflict, "Manufacturer already exists")
+			h.Logger.Warn("Duplicate manufacturer", zap.String("name", updatedData.Name))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating manufacturer")
+			h.Logger.Error("Error updating manufacturer", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Updated manufacturer", zap.String("id", updatedData.ID), zap.String("name", updatedData.Name))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteManufacturer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing manufacturer ID")
+		h.Logger.Warn("Missing manufacturer ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteManufacturer(id); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Manufacturer not found")
+			h.Logger.Warn("Manufacturer not found", zap.String("id", id))
+		case ErrManufacturerInUse:
+			errors.WriteHTTPError(w, http.StatusConflict, "Manufacturer still has brands")
+			h.Logger.Warn("Manufacturer still has brands", zap.String("id", id))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting manufacturer")
+			h.Logger.Error("Error deleting manufacturer", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Deleted manufacturer", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package brand
+
+import "time"
+
+type Manufacturer struct {
+	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Name      string    `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	Country   string    `json:"country,omitempty" validate:"omitempty,iso3166_1_alpha2"`
+	Website   string    `json:"website,omitempty" validate:"omitempty,url,max=255"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type Brand struct {
+	ID             string        `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Name           string        `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	ManufacturerID string        `json:"manufacturer_id" gorm:"type:uuid;not null" validate:"required,uuid"`
+	Manufacturer   *Manufacturer `json:"manufacturer,omitempty" validate:"-"`
+	CreatedAt      time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+}
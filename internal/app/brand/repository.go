@@ -0,0 +1,117 @@
+package brand
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetAll(manufacturerID string, limit, offset int) ([]Brand, int64, error)
+	GetByID(id string) (*Brand, error)
+	Create(brand *Brand) error
+	Update(brand *Brand) error
+	Delete(id string) error
+	CountFoods(brandID string) (int64, error)
+
+	GetManufacturers(limit, offset int) ([]Manufacturer, int64, error)
+	GetManufacturerByID(id string) (*Manufacturer, error)
+	CreateManufacturer(manufacturer *Manufacturer) error
+	UpdateManufacturer(manufacturer *Manufacturer) error
+	DeleteManufacturer(id string) error
+	CountBrands(manufacturerID string) (int64, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(manufacturerID string, limit, offset int) ([]Brand, int64, error) {
+	var brands []Brand
+	var total int64
+
+	query := r.db.Model(&Brand{})
+	if manufacturerID != "" {
+		query = query.Where("manufacturer_id = ?", manufacturerID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Manufacturer").Order("name").Limit(limit).Offset(offset).Find(&brands).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return brands, total, nil
+}
+
+func (r *repositoryImpl) GetByID(id string) (*Brand, error) {
+	var brand Brand
+	if err := r.db.Preload("Manufacturer").First(&brand, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &brand, nil
+}
+
+func (r *repositoryImpl) Create(brand *Brand) error {
+	return r.db.Omit(clause.Associations).Create(brand).Error
+}
+
+func (r *repositoryImpl) Update(brand *Brand) error {
+	return r.db.Omit(clause.Associations).Save(brand).Error
+}
+
+func (r *repositoryImpl) Delete(id string) error {
+	return r.db.Delete(&Brand{}, "id = ?", id).Error
+}
+
+func (r *repositoryImpl) CountFoods(brandID string) (int64, error) {
+	var count int64
+	err := r.db.Table("foods").Where("brand_id = ?", brandID).Count(&count).Error
+	return count, err
+}
+
+func (r *repositoryImpl) GetManufacturers(limit, offset int) ([]Manufacturer, int64, error) {
+	var manufacturers []Manufacturer
+	var total int64
+
+	if err := r.db.Model(&Manufacturer{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Order("name").Limit(limit).Offset(offset).Find(&manufacturers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return manufacturers, total, nil
+}
+
+func (r *repositoryImpl) GetManufacturerByID(id string) (*Manufacturer, error) {
+	var manufacturer Manufacturer
+	if err := r.db.First(&manufacturer, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &manufacturer, nil
+}
+
+func (r *repositoryImpl) CreateManufacturer(manufacturer *Manufacturer) error {
+	return r.db.Create(manufacturer).Error
+}
+
+func (r *repositoryImpl) UpdateManufacturer(manufacturer *Manufacturer) error {
+	return r.db.Save(manufacturer).Error
+}
+
+func (r *repositoryImpl) DeleteManufacturer(id string) error {
+	return r.db.Delete(&Manufacturer{}, "id = ?", id).Error
+}
+
+func (r *repositoryImpl) CountBrands(manufacturerID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&Brand{}).Where("manufacturer_id = ?", manufacturerID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,27 @@
+package brand
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetAll)
+	r.Post("/", h.Create)
+	r.Get("/{id}", h.GetByID)
+	r.Put("/{id}", h.Update)
+	r.Delete("/{id}", h.Delete)
+
+	return r
+}
+
+func (h *Handler) ManufacturerRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetManufacturers)
+	r.Post("/", h.CreateManufacturer)
+	r.Get("/{id}", h.GetManufacturerByID)
+	r.Put("/{id}", h.UpdateManufacturer)
+	r.Delete("/{id}", h.DeleteManufacturer)
+
+	return r
+}
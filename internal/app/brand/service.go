@@ -0,0 +1,156 @@
+package brand
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrManufacturerNotFound = errors.New("manufacturer not found")
+	ErrBrandInUse           = errors.New("brand is referenced by foods")
+	ErrManufacturerInUse    = errors.New("manufacturer is referenced by brands")
+)
+
+type Service interface {
+	GetAll(manufacturerID string, limit, offset int) ([]Brand, int64, error)
+	GetByID(id string) (*Brand, error)
+	Create(brand *Brand) error
+	Update(brand *Brand) error
+	Delete(id string) error
+
+	GetManufacturers(limit, offset int) ([]Manufacturer, int64, error)
+	GetManufacturerByID(id string) (*Manufacturer, error)
+	CreateManufacturer(manufacturer *Manufacturer) error
+	UpdateManufacturer(manufacturer *Manufacturer) error
+	DeleteManufacturer(id string) error
+}
+
+type serviceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetAll(manufacturerID string, limit, offset int) ([]Brand, int64, error) {
+	return s.repo.GetAll(manufacturerID, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(id string) (*Brand, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *serviceImpl) Create(brand *Brand) error {
+	manufacturer, err := s.getManufacturer(brand.ManufacturerID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(brand); err != nil {
+		return err
+	}
+
+	brand.Manufacturer = manufacturer
+	return nil
+}
+
+func (s *serviceImpl) Update(brand *Brand) error {
+	existingBrand, err := s.repo.GetByID(brand.ID)
+	if err != nil {
+		return err
+	}
+
+	manufacturer, err := s.getManufacturer(brand.ManufacturerID)
+	if err != nil {
+		return err
+	}
+
+	brand.CreatedAt = existingBrand.CreatedAt
+
+	if err := s.repo.Update(brand); err != nil {
+		return err
+	}
+
+	brand.Manufacturer = manufacturer
+	return nil
+}
+
+func (s *serviceImpl) Delete(id string) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountFoods(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrBrandInUse
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrBrandInUse
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) GetManufacturers(limit, offset int) ([]Manufacturer, int64, error) {
+	return s.repo.GetManufacturers(limit, offset)
+}
+
+func (s *serviceImpl) GetManufacturerByID(id string) (*Manufacturer, error) {
+	return s.repo.GetManufacturerByID(id)
+}
+
+func (s *serviceImpl) CreateManufacturer(manufacturer *Manufacturer) error {
+	return s.repo.CreateManufacturer(manufacturer)
+}
+
+func (s *serviceImpl) UpdateManufacturer(manufacturer *Manufacturer) error {
+	existingManufacturer, err := s.repo.GetManufacturerByID(manufacturer.ID)
+	if err != nil {
+		return err
+	}
+
+	manufacturer.CreatedAt = existingManufacturer.CreatedAt
+
+	return s.repo.UpdateManufacturer(manufacturer)
+}
+
+func (s *serviceImpl) DeleteManufacturer(id string) error {
+	if _, err := s.repo.GetManufacturerByID(id); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountBrands(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrManufacturerInUse
+	}
+
+	if err := s.repo.DeleteManufacturer(id); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrManufacturerInUse
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) getManufacturer(id string) (*Manufacturer, error) {
+	manufacturer, err := s.repo.GetManufacturerByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrManufacturerNotFound
+		}
+		return nil, err
+	}
+	return manufacturer, nil
+}
@@ -0,0 +1,19 @@
+package diary
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/app/hydration"
+	"github.com/v-vovk/health-tracker-api/internal/app/medication"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger, foods *food.Service, hydration hydration.Service, medication medication.Service) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, foods, hydration, medication, logger.Named("DiaryService"))
+	validator := validator.New()
+	diaryLogger := logger.Named("DiaryHandler")
+
+	return NewHandler(service, validator, diaryLogger)
+}
@@ -0,0 +1,467 @@
+package diary
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetMeals(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	meals, total, err := h.Service.GetMeals(userID, period, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving meals")
+		h.Logger.Error("Error retrieving meals", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     meals,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(meals),
+	}
+
+	h.Logger.Info("Retrieved meals", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(meals)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateMeal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var meal Meal
+	if err := json.NewDecoder(r.Body).Decode(&meal); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(meal); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateMeal(userID, &meal); err != nil {
+		h.writeError(w, err, "", "", "Error creating meal")
+		return
+	}
+
+	h.Logger.Info("Created new meal", zap.String("id", meal.ID), zap.String("type", meal.Type))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(meal); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetMealByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing meal ID")
+		h.Logger.Warn("Missing meal ID in request")
+		return
+	}
+
+	meal, err := h.Service.GetMealByID(userID, id)
+	if err != nil {
+		h.writeError(w, err, id, "", "Error retrieving meal")
+		return
+	}
+
+	h.Logger.Info("Retrieved meal", zap.String("id", meal.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(meal); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateMeal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing meal ID")
+		h.Logger.Warn("Missing meal ID in request")
+		return
+	}
+
+	var updatedData Meal
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateMeal(userID, &updatedData); err != nil {
+		h.writeError(w, err, id, "", "Error updating meal")
+		return
+	}
+
+	h.Logger.Info("Updated meal", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteMeal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing meal ID")
+		h.Logger.Warn("Missing meal ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteMeal(userID, id); err != nil {
+		h.writeError(w, err, id, "", "Error deleting meal")
+		return
+	}
+
+	h.Logger.Info("Deleted meal", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetEntries lists entries across all meals, or of a single meal when mounted
+// below /meals/{id}.
+func (h *Handler) GetEntries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	filter := EntryFilter{
+		Range:  period,
+		MealID: chi.URLParam(r, "id"),
+		FoodID: r.URL.Query().Get("food_id"),
+	}
+
+	entries, total, err := h.Service.GetEntries(userID, filter, limit, offset)
+	if err != nil {
+		h.writeError(w, err, filter.MealID, "", "Error retrieving diary entries")
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     entries,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(entries),
+	}
+
+	h.Logger.Info("Retrieved diary entries", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(entries)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateEntry(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing meal ID")
+		h.Logger.Warn("Missing meal ID in request")
+		return
+	}
+
+	var entry Entry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(entry); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	entry.ID = ""
+	entry.MealID = id
+	if err := h.Service.CreateEntry(userID, &entry); err != nil {
+		h.writeError(w, err, id, "", "Error creating diary entry")
+		return
+	}
+
+	h.Logger.Info("Created diary entry", zap.String("meal_id", id), zap.String("id", entry.ID), zap.String("food_id", entry.FoodID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetEntry(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	entryID := chi.URLParam(r, "entryId")
+	if id == "" || entryID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing meal or entry ID")
+		h.Logger.Warn("Missing meal or entry ID in request")
+		return
+	}
+
+	entry, err := h.Service.GetEntry(userID, id, entryID)
+	if err != nil {
+		h.writeError(w, err, id, entryID, "Error retrieving diary entry")
+		return
+	}
+
+	h.Logger.Info("Retrieved diary entry", zap.String("meal_id", id), zap.String("id", entryID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateEntry(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	entryID := chi.URLParam(r, "entryId")
+	if id == "" || entryID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing meal or entry ID")
+		h.Logger.Warn("Missing meal or entry ID in request")
+		return
+	}
+
+	var entry Entry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(entry); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	entry.ID = entryID
+	entry.MealID = id
+	if err := h.Service.UpdateEntry(userID, &entry); err != nil {
+		h.writeError(w, err, id, entryID, "Error updating diary entry")
+		return
+	}
+
+	h.Logger.Info("Updated diary entry", zap.String("meal_id", id), zap.String("id", entryID))
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	entryID := chi.URLParam(r, "entryId")
+	if id == "" || entryID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing meal or entry ID")
+		h.Logger.Warn("Missing meal or entry ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteEntry(userID, id, entryID); err != nil {
+		h.writeError(w, err, id, entryID, "Error deleting diary entry")
+		return
+	}
+
+	h.Logger.Info("Deleted diary entry", zap.String("meal_id", id), zap.String("id", entryID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDaySummary returns the nutrition totals of a day. The day starts at local
+// midnight of the optional "tz" query parameter (an IANA time zone, the profile
+// time zone by default).
+func (h *Handler) GetDaySummary(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	date := chi.URLParam(r, "date")
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	parsed, err := db.ParseDate(date)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+		h.Logger.Warn("Invalid date", zap.String("date", date))
+		return
+	}
+	day := parsed.In(location)
+
+	summary, err := h.Service.GetDaySummary(userID, day)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating day summary")
+		h.Logger.Error("Error calculating day summary", zap.String("date", date), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Calculated day summary", zap.String("date", date), zap.Int("meals", len(summary.Meals)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error, mealID, entryID, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound, ErrMealNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Meal not found")
+		h.Logger.Warn("Meal not found", zap.String("id", mealID))
+	case ErrEntryNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Diary entry not found")
+		h.Logger.Warn("Diary entry not found", zap.String("meal_id", mealID), zap.String("id", entryID))
+	case ErrFoodNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+		h.Logger.Warn("Food not found", zap.String("meal_id", mealID))
+	case food.ErrUnknownUnit:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Unknown unit")
+		h.Logger.Warn("Unknown unit", zap.String("meal_id", mealID))
+	case food.ErrDensityRequired:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Food has no density, volume units cannot be used")
+		h.Logger.Warn("Volume unit without density", zap.String("meal_id", mealID))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("meal_id", mealID), zap.String("id", entryID), zap.Error(err))
+	}
+}
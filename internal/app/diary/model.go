@@ -0,0 +1,88 @@
+package diary
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/app/hydration"
+)
+
+// Meal types. A custom meal needs a name.
+const (
+	MealBreakfast = "breakfast"
+	MealLunch     = "lunch"
+	MealDinner    = "dinner"
+	MealSnack     = "snack"
+	MealCustom    = "custom"
+)
+
+type Meal struct {
+	ID         string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string    `json:"-" gorm:"type:uuid;not null"`
+	Type       string    `json:"type" gorm:"not null" validate:"required,oneof=breakfast lunch dinner snack custom"`
+	Name       string    `json:"name" gorm:"not null;default:''" validate:"required_if=Type custom,max=50"`
+	ConsumedAt time.Time `json:"consumed_at" gorm:"not null" validate:"required"`
+	Notes      string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	Entries    []Entry   `json:"entries" gorm:"foreignKey:MealID" validate:"max=100,dive"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Entry is an amount of a food eaten as part of a meal. ConsumedAt defaults to
+// the meal's time and Grams is resolved from Amount and Unit when it is saved.
+type Entry struct {
+	ID         string     `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string     `json:"-" gorm:"type:uuid;not null"`
+	MealID     string     `json:"meal_id" gorm:"type:uuid;not null"`
+	FoodID     string     `json:"food_id" gorm:"type:uuid;not null" validate:"required,uuid"`
+	Amount     float64    `json:"amount" gorm:"not null" validate:"required,gt=0,lte=100000"`
+	Unit       string     `json:"unit" gorm:"not null" validate:"required,max=50"`
+	Grams      float64    `json:"grams" gorm:"not null"`
+	ConsumedAt time.Time  `json:"consumed_at" gorm:"not null"`
+	Food       *food.Food `json:"food,omitempty" validate:"-"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Entry) TableName() string {
+	return "diary_entries"
+}
+
+// NutrientTotal is the summed amount of a micronutrient in its canonical unit.
+type NutrientTotal struct {
+	NutrientID string  `json:"nutrient_id"`
+	Code       string  `json:"code"`
+	Name       string  `json:"name"`
+	Unit       string  `json:"unit"`
+	Amount     float64 `json:"amount"`
+}
+
+// Totals is the nutrition of a set of diary entries.
+type Totals struct {
+	Entries int     `json:"entries"`
+	Grams   float64 `json:"grams"`
+	food.Nutrition
+	Nutrients []NutrientTotal `json:"nutrients" gorm:"-"`
+}
+
+type MealSummary struct {
+	MealID     string    `json:"meal_id"`
+	Type       string    `json:"type"`
+	Name       string    `json:"name"`
+	ConsumedAt time.Time `json:"consumed_at"`
+	Totals
+}
+
+// DaySummary holds the nutrition totals of a day, overall and per meal. The
+// nutrients of supplement doses taken that day are listed in Supplements and
+// included in the overall Totals.
+type DaySummary struct {
+	Date        string          `json:"date"`
+	TimeZone    string          `json:"time_zone"`
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	Totals      Totals          `json:"totals"`
+	Meals       []MealSummary   `json:"meals"`
+	Supplements []NutrientTotal `json:"supplements"`
+	Hydration   *hydration.Day  `json:"hydration"`
+}
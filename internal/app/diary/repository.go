@@ -0,0 +1,232 @@
+package diary
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// mealTotalsSQL sums the energy and macronutrients of a user's entries in a range per
+// meal and, in the row without a meal, for the whole range.
+const mealTotalsSQL = `
+SELECT e.meal_id,
+       m.type,
+       m.name,
+       m.consumed_at,
+       COUNT(e.id)                                         AS entries,
+       COALESCE(SUM(e.grams), 0)                           AS grams,
+       COALESCE(SUM(f.energy_kcal * e.grams / 100), 0)     AS energy_kcal,
+       COALESCE(SUM(f.energy_kj * e.grams / 100), 0)       AS energy_kj,
+       COALESCE(SUM(f.protein_g * e.grams / 100), 0)       AS protein_g,
+       COALESCE(SUM(f.fat_g * e.grams / 100), 0)           AS fat_g,
+       COALESCE(SUM(f.saturated_fat_g * e.grams / 100), 0) AS saturated_fat_g,
+       COALESCE(SUM(f.carbohydrate_g * e.grams / 100), 0)  AS carbohydrate_g,
+       COALESCE(SUM(f.sugar_g * e.grams / 100), 0)         AS sugar_g,
+       COALESCE(SUM(f.fiber_g * e.grams / 100), 0)         AS fiber_g,
+       COALESCE(SUM(f.sodium_mg * e.grams / 100), 0)       AS sodium_mg
+FROM diary_entries e
+         JOIN meals m ON m.id = e.meal_id
+         JOIN foods f ON f.id = e.food_id
+WHERE e.user_id = ?
+  AND e.consumed_at >= ?
+  AND e.consumed_at < ?
+GROUP BY GROUPING SETS ((e.meal_id, m.type, m.name, m.consumed_at), ())
+ORDER BY m.consumed_at NULLS FIRST, e.meal_id`
+
+// nutrientTotalsSQL sums the micronutrients of a user's entries in a range per meal
+// and, in the rows without a meal, for the whole range.
+const nutrientTotalsSQL = `
+SELECT e.meal_id,
+       n.id                           AS nutrient_id,
+       n.code,
+       n.name,
+       n.unit,
+       SUM(fn.amount * e.grams / 100) AS amount
+FROM diary_entries e
+         JOIN food_nutrients fn ON fn.food_id = e.food_id
+         JOIN nutrients n ON n.id = fn.nutrient_id
+WHERE e.user_id = ?
+  AND e.consumed_at >= ?
+  AND e.consumed_at < ?
+GROUP BY GROUPING SETS ((e.meal_id, n.id, n.code, n.name, n.unit), (n.id, n.code, n.name, n.unit))
+ORDER BY n.code`
+
+// MealTotalsRow is a row of mealTotalsSQL. The meal fields are nil in the row
+// holding the totals of the whole range.
+type MealTotalsRow struct {
+	MealID     *string
+	Type       *string
+	Name       *string
+	ConsumedAt *time.Time
+	Totals
+}
+
+// NutrientTotalsRow is a row of nutrientTotalsSQL. MealID is nil in the rows
+// holding the totals of the whole range.
+type NutrientTotalsRow struct {
+	MealID *string
+	NutrientTotal
+}
+
+// EntryFilter narrows down the entries returned by Repository.GetEntries.
+type EntryFilter struct {
+	reporting.Range
+	// MealID restricts the result to the entries of a single meal.
+	MealID string
+	// FoodID restricts the result to entries of a single food.
+	FoodID string
+}
+
+type Repository interface {
+	GetMeals(userID string, period reporting.Range, limit, offset int) ([]Meal, int64, error)
+	GetMealByID(userID, id string) (*Meal, error)
+	CreateMeal(meal *Meal) error
+	UpdateMeal(meal *Meal) error
+	DeleteMeal(userID, id string) error
+
+	GetEntries(userID string, filter EntryFilter, limit, offset int) ([]Entry, int64, error)
+	GetEntry(userID, mealID, entryID string) (*Entry, error)
+	CreateEntry(entry *Entry) error
+	UpdateEntry(entry *Entry) error
+	DeleteEntry(userID, mealID, entryID string) error
+
+	GetMealTotals(userID string, period reporting.Range) ([]MealTotalsRow, error)
+	GetNutrientTotals(userID string, period reporting.Range) ([]NutrientTotalsRow, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetMeals(userID string, period reporting.Range, limit, offset int) ([]Meal, int64, error) {
+	var meals []Meal
+	var total int64
+
+	query := inRange(r.db.Model(&Meal{}).Where("user_id = ?", userID), "consumed_at", period)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Entries", orderByConsumedAt).
+		Preload("Entries.Food").
+		Order("consumed_at, id").
+		Limit(limit).Offset(offset).
+		Find(&meals).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return meals, total, nil
+}
+
+func (r *repositoryImpl) GetMealByID(userID, id string) (*Meal, error) {
+	var meal Meal
+	err := r.db.Preload("Entries", orderByConsumedAt).
+		Preload("Entries.Food").
+		First(&meal, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &meal, nil
+}
+
+// CreateMeal stores the meal together with its entries.
+func (r *repositoryImpl) CreateMeal(meal *Meal) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(meal).Error; err != nil {
+			return err
+		}
+
+		if len(meal.Entries) == 0 {
+			return nil
+		}
+		for i := range meal.Entries {
+			meal.Entries[i].MealID = meal.ID
+		}
+		return tx.Omit(clause.Associations).Create(&meal.Entries).Error
+	})
+}
+
+func (r *repositoryImpl) UpdateMeal(meal *Meal) error {
+	return r.db.Omit(clause.Associations).Save(meal).Error
+}
+
+func (r *repositoryImpl) DeleteMeal(userID, id string) error {
+	return r.db.Delete(&Meal{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetEntries(userID string, filter EntryFilter, limit, offset int) ([]Entry, int64, error) {
+	var entries []Entry
+	var total int64
+
+	query := inRange(r.db.Model(&Entry{}).Where("user_id = ?", userID), "consumed_at", filter.Range)
+	if filter.MealID != "" {
+		query = query.Where("meal_id = ?", filter.MealID)
+	}
+	if filter.FoodID != "" {
+		query = query.Where("food_id = ?", filter.FoodID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Food").Order("consumed_at, id").Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *repositoryImpl) GetEntry(userID, mealID, entryID string) (*Entry, error) {
+	var entry Entry
+	if err := r.db.Preload("Food").First(&entry, "meal_id = ? AND id = ? AND user_id = ?", mealID, entryID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *repositoryImpl) CreateEntry(entry *Entry) error {
+	return r.db.Omit(clause.Associations).Create(entry).Error
+}
+
+func (r *repositoryImpl) UpdateEntry(entry *Entry) error {
+	return r.db.Omit(clause.Associations).Save(entry).Error
+}
+
+func (r *repositoryImpl) DeleteEntry(userID, mealID, entryID string) error {
+	return r.db.Delete(&Entry{}, "meal_id = ? AND id = ? AND user_id = ?", mealID, entryID, userID).Error
+}
+
+func (r *repositoryImpl) GetMealTotals(userID string, period reporting.Range) ([]MealTotalsRow, error) {
+	var rows []MealTotalsRow
+	err := r.db.Raw(mealTotalsSQL, userID, period.From, period.To).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repositoryImpl) GetNutrientTotals(userID string, period reporting.Range) ([]NutrientTotalsRow, error) {
+	var rows []NutrientTotalsRow
+	err := r.db.Raw(nutrientTotalsSQL, userID, period.From, period.To).Scan(&rows).Error
+	return rows, err
+}
+
+func inRange(query *gorm.DB, column string, period reporting.Range) *gorm.DB {
+	if !period.From.IsZero() {
+		query = query.Where(column+" >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where(column+" < ?", period.To)
+	}
+	return query
+}
+
+func orderByConsumedAt(db *gorm.DB) *gorm.DB {
+	return db.Order("consumed_at, id")
+}
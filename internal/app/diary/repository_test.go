@@ -0,0 +1,61 @@
+package diary
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db/dbtest"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+func TestRepositoryScopesToUser(t *testing.T) {
+	database, recorder := dbtest.Open(t)
+	repo := NewRepository(database)
+	period := reporting.Range{From: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name  string
+		scope string
+		call  func() error
+	}{
+		{name: "GetMealByID", scope: "user_id = 'user-1'", call: func() error {
+			_, err := repo.GetMealByID("user-1", "meal-1")
+			return err
+		}},
+		{name: "DeleteMeal", scope: "user_id = 'user-1'", call: func() error { return repo.DeleteMeal("user-1", "meal-1") }},
+		{name: "GetEntry", scope: "user_id = 'user-1'", call: func() error {
+			_, err := repo.GetEntry("user-1", "meal-1", "entry-1")
+			return err
+		}},
+		{name: "DeleteEntry", scope: "user_id = 'user-1'", call: func() error { return repo.DeleteEntry("user-1", "meal-1", "entry-1") }},
+		{name: "GetEntries of a meal", scope: "user_id = 'user-1'", call: func() error {
+			_, _, err := repo.GetEntries("user-1", EntryFilter{Range: period, MealID: "meal-1"}, 10, 0)
+			return err
+		}},
+		{name: "GetMealTotals", scope: "e.user_id = 'user-1'", call: func() error {
+			_, err := repo.GetMealTotals("user-1", period)
+			return err
+		}},
+		{name: "GetNutrientTotals", scope: "e.user_id = 'user-1'", call: func() error {
+			_, err := repo.GetNutrientTotals("user-1", period)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Statements = nil
+			// A dry run finds no rows, so only the statements are checked.
+			_ = tt.call()
+			if len(recorder.Statements) == 0 {
+				t.Fatal("no statement sent")
+			}
+			for _, statement := range recorder.Statements {
+				if !strings.Contains(statement, tt.scope) {
+					t.Errorf("statement not scoped to the user: %s", statement)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package diary
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/meals", h.GetMeals)
+	r.Post("/meals", h.CreateMeal)
+	r.Get("/meals/{id}", h.GetMealByID)
+	r.Put("/meals/{id}", h.UpdateMeal)
+	r.Delete("/meals/{id}", h.DeleteMeal)
+
+	r.Get("/meals/{id}/entries", h.GetEntries)
+	r.Post("/meals/{id}/entries", h.CreateEntry)
+	r.Get("/meals/{id}/entries/{entryId}", h.GetEntry)
+	r.Put("/meals/{id}/entries/{entryId}", h.UpdateEntry)
+	r.Delete("/meals/{id}/entries/{entryId}", h.DeleteEntry)
+
+	r.Get("/entries", h.GetEntries)
+
+	r.Get("/days/{date}/summary", h.GetDaySummary)
+
+	return r
+}
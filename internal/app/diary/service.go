@@ -0,0 +1,359 @@
+package diary
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/app/hydration"
+	"github.com/v-vovk/health-tracker-api/internal/app/medication"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMealNotFound  = errors.New("meal not found")
+	ErrEntryNotFound = errors.New("diary entry not found")
+	ErrFoodNotFound  = errors.New("food not found")
+)
+
+// EntryListener is notified after a user's entries have been saved, e.g. to end
+// a fast. The change is already stored, so listener errors are logged rather
+// than returned.
+type EntryListener interface {
+	// EntriesLogged receives new entries and the current version of changed ones.
+	EntriesLogged(userID string, entries []Entry) error
+	// EntriesRemoved receives deleted entries and the previous version of changed ones.
+	EntriesRemoved(userID string, entries []Entry) error
+}
+
+type Service interface {
+	GetMeals(userID string, period reporting.Range, limit, offset int) ([]Meal, int64, error)
+	GetMealByID(userID, id string) (*Meal, error)
+	CreateMeal(userID string, meal *Meal) error
+	UpdateMeal(userID string, meal *Meal) error
+	DeleteMeal(userID, id string) error
+
+	GetEntries(userID string, filter EntryFilter, limit, offset int) ([]Entry, int64, error)
+	GetEntry(userID, mealID, entryID string) (*Entry, error)
+	CreateEntry(userID string, entry *Entry) error
+	UpdateEntry(userID string, entry *Entry) error
+	DeleteEntry(userID, mealID, entryID string) error
+
+	// GetDaySummary sums the user's entries of the day starting at the given local midnight.
+	GetDaySummary(userID string, day time.Time) (*DaySummary, error)
+
+	Subscribe(listener EntryListener)
+}
+
+type serviceImpl struct {
+	repo       Repository
+	foods      *food.Service
+	hydration  hydration.Service
+	medication medication.Service
+	listeners  []EntryListener
+	logger     *zap.Logger
+}
+
+func NewService(repo Repository, foods *food.Service, hydration hydration.Service, medication medication.Service, logger *zap.Logger) Service {
+	return &serviceImpl{repo: repo, foods: foods, hydration: hydration, medication: medication, logger: logger}
+}
+
+func (s *serviceImpl) GetMeals(userID string, period reporting.Range, limit, offset int) ([]Meal, int64, error) {
+	return s.repo.GetMeals(userID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetMealByID(userID, id string) (*Meal, error) {
+	return s.repo.GetMealByID(userID, id)
+}
+
+func (s *serviceImpl) CreateMeal(userID string, meal *Meal) error {
+	meal.UserID = userID
+	for i := range meal.Entries {
+		if err := s.prepareEntry(meal, &meal.Entries[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.CreateMeal(meal); err != nil {
+		return err
+	}
+
+	created, err := s.repo.GetMealByID(userID, meal.ID)
+	if err != nil {
+		return err
+	}
+	*meal = *created
+	s.notify(userID, nil, meal.Entries)
+	return nil
+}
+
+// UpdateMeal changes the meal itself; entries are managed through the entry methods.
+func (s *serviceImpl) UpdateMeal(userID string, meal *Meal) error {
+	existing, err := s.repo.GetMealByID(userID, meal.ID)
+	if err != nil {
+		return err
+	}
+
+	existing.Type = meal.Type
+	existing.Name = meal.Name
+	existing.ConsumedAt = meal.ConsumedAt
+	existing.Notes = meal.Notes
+	if err := s.repo.UpdateMeal(existing); err != nil {
+		return err
+	}
+
+	*meal = *existing
+	// The entries stay as they are, but the meal type they count for may have changed.
+	s.notify(userID, nil, meal.Entries)
+	return nil
+}
+
+func (s *serviceImpl) DeleteMeal(userID, id string) error {
+	meal, err := s.repo.GetMealByID(userID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteMeal(userID, id); err != nil {
+		return err
+	}
+
+	s.notify(userID, meal.Entries, nil)
+	return nil
+}
+
+func (s *serviceImpl) GetEntries(userID string, filter EntryFilter, limit, offset int) ([]Entry, int64, error) {
+	if filter.MealID != "" {
+		if _, err := s.getMeal(userID, filter.MealID); err != nil {
+			return nil, 0, err
+		}
+	}
+	return s.repo.GetEntries(userID, filter, limit, offset)
+}
+
+func (s *serviceImpl) GetEntry(userID, mealID, entryID string) (*Entry, error) {
+	if _, err := s.getMeal(userID, mealID); err != nil {
+		return nil, err
+	}
+	return s.getEntry(userID, mealID, entryID)
+}
+
+func (s *serviceImpl) CreateEntry(userID string, entry *Entry) error {
+	meal, err := s.getMeal(userID, entry.MealID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.prepareEntry(meal, entry); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateEntry(entry); err != nil {
+		return err
+	}
+
+	created, err := s.repo.GetEntry(userID, entry.MealID, entry.ID)
+	if err != nil {
+		return err
+	}
+	*entry = *created
+	s.notify(userID, nil, []Entry{*entry})
+	return nil
+}
+
+func (s *serviceImpl) UpdateEntry(userID string, entry *Entry) error {
+	meal, err := s.getMeal(userID, entry.MealID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.getEntry(userID, entry.MealID, entry.ID)
+	if err != nil {
+		return err
+	}
+
+	entry.CreatedAt = existing.CreatedAt
+	if err := s.prepareEntry(meal, entry); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateEntry(entry); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.GetEntry(userID, entry.MealID, entry.ID)
+	if err != nil {
+		return err
+	}
+	*entry = *updated
+	s.notify(userID, []Entry{*existing}, []Entry{*entry})
+	return nil
+}
+
+func (s *serviceImpl) DeleteEntry(userID, mealID, entryID string) error {
+	if _, err := s.getMeal(userID, mealID); err != nil {
+		return err
+	}
+	entry, err := s.getEntry(userID, mealID, entryID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteEntry(userID, mealID, entryID); err != nil {
+		return err
+	}
+
+	s.notify(userID, []Entry{*entry}, nil)
+	return nil
+}
+
+func (s *serviceImpl) GetDaySummary(userID string, day time.Time) (*DaySummary, error) {
+	period := reporting.Range{From: day, To: day.AddDate(0, 0, 1)}
+
+	mealRows, err := s.repo.GetMealTotals(userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	nutrientRows, err := s.repo.GetNutrientTotals(userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	supplements, err := s.medication.GetNutrientIntake(userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	water, err := s.hydration.GetDay(userID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &DaySummary{
+		Date:        day.Format(db.DateLayout),
+		TimeZone:    day.Location().String(),
+		From:        period.From,
+		To:          period.To,
+		Meals:       []MealSummary{},
+		Supplements: []NutrientTotal{},
+		Hydration:   water,
+	}
+
+	mealIndex := map[string]int{}
+	for _, row := range mealRows {
+		row.Nutrition = row.Nutrition.Round(2)
+		row.Grams = reporting.Round(row.Grams, 2)
+		row.Nutrients = []NutrientTotal{}
+		if row.MealID == nil {
+			summary.Totals = row.Totals
+			continue
+		}
+
+		mealIndex[*row.MealID] = len(summary.Meals)
+		summary.Meals = append(summary.Meals, MealSummary{
+			MealID:     *row.MealID,
+			Type:       *row.Type,
+			Name:       *row.Name,
+			ConsumedAt: *row.ConsumedAt,
+			Totals:     row.Totals,
+		})
+	}
+
+	for _, row := range nutrientRows {
+		row.Amount = reporting.Round(row.Amount, 3)
+		if row.MealID == nil {
+			summary.Totals.Nutrients = append(summary.Totals.Nutrients, row.NutrientTotal)
+			continue
+		}
+
+		if i, ok := mealIndex[*row.MealID]; ok {
+			summary.Meals[i].Nutrients = append(summary.Meals[i].Nutrients, row.NutrientTotal)
+		}
+	}
+
+	if len(supplements) > 0 {
+		totalIndex := map[string]int{}
+		for i, total := range summary.Totals.Nutrients {
+			totalIndex[total.NutrientID] = i
+		}
+		for _, intake := range supplements {
+			total := NutrientTotal(intake)
+			total.Amount = reporting.Round(total.Amount, 3)
+			summary.Supplements = append(summary.Supplements, total)
+
+			if i, ok := totalIndex[total.NutrientID]; ok {
+				summary.Totals.Nutrients[i].Amount = reporting.Round(summary.Totals.Nutrients[i].Amount+total.Amount, 3)
+				continue
+			}
+			summary.Totals.Nutrients = append(summary.Totals.Nutrients, total)
+		}
+		sort.Slice(summary.Totals.Nutrients, func(i, j int) bool {
+			return summary.Totals.Nutrients[i].Code < summary.Totals.Nutrients[j].Code
+		})
+	}
+
+	return summary, nil
+}
+
+func (s *serviceImpl) Subscribe(listener EntryListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// notify passes removed and logged entries to the listeners, removals first.
+func (s *serviceImpl) notify(userID string, removed, logged []Entry) {
+	for _, listener := range s.listeners {
+		if len(removed) > 0 {
+			if err := listener.EntriesRemoved(userID, removed); err != nil {
+				s.logger.Error("Entry listener failed on removed entries", zap.Error(err))
+			}
+		}
+		if len(logged) > 0 {
+			if err := listener.EntriesLogged(userID, logged); err != nil {
+				s.logger.Error("Entry listener failed on logged entries", zap.Error(err))
+			}
+		}
+	}
+}
+
+// prepareEntry resolves the entry's grams and defaults its time to the meal's.
+func (s *serviceImpl) prepareEntry(meal *Meal, entry *Entry) error {
+	grams, err := s.foods.ToGrams(entry.FoodID, entry.Amount, entry.Unit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFoodNotFound
+		}
+		return err
+	}
+
+	entry.UserID = meal.UserID
+	entry.Grams = grams
+	if entry.ConsumedAt.IsZero() {
+		entry.ConsumedAt = meal.ConsumedAt
+	}
+	return nil
+}
+
+func (s *serviceImpl) getMeal(userID, mealID string) (*Meal, error) {
+	meal, err := s.repo.GetMealByID(userID, mealID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMealNotFound
+		}
+		return nil, err
+	}
+	return meal, nil
+}
+
+func (s *serviceImpl) getEntry(userID, mealID, entryID string) (*Entry, error) {
+	entry, err := s.repo.GetEntry(userID, mealID, entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,127 @@
+package diary
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/app/hydration"
+	"github.com/v-vovk/health-tracker-api/internal/app/medication"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+)
+
+// fakeRepository returns fixed rows of the totals queries and records their range.
+type fakeRepository struct {
+	Repository
+	meals     []MealTotalsRow
+	nutrients []NutrientTotalsRow
+	periods   []reporting.Range
+}
+
+func (r *fakeRepository) GetMealTotals(userID string, period reporting.Range) ([]MealTotalsRow, error) {
+	r.periods = append(r.periods, period)
+	return r.meals, nil
+}
+
+func (r *fakeRepository) GetNutrientTotals(userID string, period reporting.Range) ([]NutrientTotalsRow, error) {
+	r.periods = append(r.periods, period)
+	return r.nutrients, nil
+}
+
+type fakeHydration struct {
+	hydration.Service
+}
+
+func (fakeHydration) GetDay(userID string, day time.Time) (*hydration.Day, error) {
+	return &hydration.Day{Date: day.Format("2006-01-02")}, nil
+}
+
+type fakeMedication struct {
+	medication.Service
+	intake []medication.NutrientIntake
+}
+
+func (m fakeMedication) GetNutrientIntake(userID string, period reporting.Range) ([]medication.NutrientIntake, error) {
+	return m.intake, nil
+}
+
+func TestGetDaySummary(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, kyiv)
+	breakfast, lunch := "breakfast", "lunch"
+	mealType, name := "breakfast", "Oats"
+	consumedAt := day.Add(8 * time.Hour)
+	vitaminC := NutrientTotal{NutrientID: "n-c", Code: "vitamin_c", Unit: "mg"}
+	iron := NutrientTotal{NutrientID: "n-fe", Code: "iron", Unit: "mg"}
+
+	repo := &fakeRepository{
+		meals: []MealTotalsRow{
+			{Totals: Totals{Entries: 3, Grams: 450.004, Nutrition: food.Nutrition{EnergyKcal: 612.345}}},
+			{MealID: &breakfast, Type: &mealType, Name: &name, ConsumedAt: &consumedAt,
+				Totals: Totals{Entries: 2, Grams: 250, Nutrition: food.Nutrition{EnergyKcal: 400.001}}},
+			{MealID: &lunch, Type: &mealType, Name: &name, ConsumedAt: &consumedAt,
+				Totals: Totals{Entries: 1, Grams: 200.004, Nutrition: food.Nutrition{EnergyKcal: 212.344}}},
+		},
+		nutrients: []NutrientTotalsRow{
+			{NutrientTotal: withAmount(vitaminC, 30.0004)},
+			{MealID: &breakfast, NutrientTotal: withAmount(vitaminC, 30.0004)},
+			{MealID: &lunch, NutrientTotal: withAmount(iron, 0)},
+		},
+	}
+	medications := fakeMedication{intake: []medication.NutrientIntake{
+		medication.NutrientIntake(withAmount(vitaminC, 500)),
+		medication.NutrientIntake(withAmount(iron, 14)),
+	}}
+	service := NewService(repo, nil, fakeHydration{}, medications, zap.NewNop())
+
+	summary, err := service.GetDaySummary("user-1", day)
+	if err != nil {
+		t.Fatalf("GetDaySummary() = %v", err)
+	}
+
+	wantPeriod := reporting.Range{From: day, To: time.Date(2026, 3, 3, 0, 0, 0, 0, kyiv)}
+	for _, period := range repo.periods {
+		if period != wantPeriod {
+			t.Errorf("GetDaySummary() queried %v, want %v", period, wantPeriod)
+		}
+	}
+	if summary.Date != "2026-03-02" || summary.TimeZone != "Europe/Kyiv" {
+		t.Errorf("GetDaySummary() day = %s %s, want 2026-03-02 Europe/Kyiv", summary.Date, summary.TimeZone)
+	}
+
+	if summary.Totals.Entries != 3 || summary.Totals.Grams != 450 || summary.Totals.EnergyKcal != 612.35 {
+		t.Errorf("GetDaySummary() totals = %+v, want 3 entries, 450 g, 612.35 kcal", summary.Totals)
+	}
+	wantTotals := []NutrientTotal{withAmount(iron, 14), withAmount(vitaminC, 530)}
+	if !reflect.DeepEqual(summary.Totals.Nutrients, wantTotals) {
+		t.Errorf("GetDaySummary() total nutrients = %+v, want %+v", summary.Totals.Nutrients, wantTotals)
+	}
+	if len(summary.Supplements) != 2 {
+		t.Errorf("GetDaySummary() supplements = %+v, want 2", summary.Supplements)
+	}
+
+	if len(summary.Meals) != 2 {
+		t.Fatalf("GetDaySummary() meals = %+v, want 2", summary.Meals)
+	}
+	if got := summary.Meals[0]; got.MealID != breakfast || got.EnergyKcal != 400 ||
+		!reflect.DeepEqual(got.Nutrients, []NutrientTotal{withAmount(vitaminC, 30)}) {
+		t.Errorf("GetDaySummary() breakfast = %+v", got)
+	}
+	if got := summary.Meals[1]; got.MealID != lunch || got.Grams != 200 ||
+		!reflect.DeepEqual(got.Nutrients, []NutrientTotal{withAmount(iron, 0)}) {
+		t.Errorf("GetDaySummary() lunch = %+v", got)
+	}
+	if summary.Hydration == nil || summary.Hydration.Date != "2026-03-02" {
+		t.Errorf("GetDaySummary() hydration = %+v, want the day's", summary.Hydration)
+	}
+}
+
+func withAmount(total NutrientTotal, amount float64) NutrientTotal {
+	total.Amount = amount
+	return total
+}
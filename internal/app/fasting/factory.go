@@ -0,0 +1,18 @@
+package fasting
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger, diary diary.Service) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, diary)
+	diary.Subscribe(service)
+	validator := validator.New()
+	fastingLogger := logger.Named("FastingHandler")
+
+	return NewHandler(service, validator, fastingLogger)
+}
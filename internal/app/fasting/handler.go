@@ -0,0 +1,367 @@
+package fasting
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultHistoryWeeks is the number of weeks of history reported without "from".
+const defaultHistoryWeeks = 8
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+// stopRequest is the optional body of a stop request; the fast ends now
+// without EndedAt.
+type stopRequest struct {
+	EndedAt *time.Time `json:"ended_at"`
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetProtocols(w http.ResponseWriter, r *http.Request) {
+	protocols := Protocols()
+
+	h.Logger.Info("Retrieved fasting protocols", zap.Int("returned", len(protocols)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(protocols); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	sessions, total, err := h.Service.GetAll(userID, period, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving fasting sessions")
+		h.Logger.Error("Error retrieving fasting sessions", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     sessions,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(sessions),
+	}
+
+	h.Logger.Info("Retrieved fasting sessions", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(sessions)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var session Session
+	if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(userID, &session); err != nil {
+		h.writeSessionError(w, err, "", "Error creating fasting session")
+		return
+	}
+
+	h.Logger.Info("Created new fasting session", zap.String("id", session.ID), zap.String("protocol", session.Protocol))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetSessionByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing fasting session ID")
+		h.Logger.Warn("Missing fasting session ID in request")
+		return
+	}
+
+	session, err := h.Service.GetByID(userID, id)
+	if err != nil {
+		h.writeSessionError(w, err, id, "Error retrieving fasting session")
+		return
+	}
+
+	h.Logger.Info("Retrieved fasting session", zap.String("id", session.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing fasting session ID")
+		h.Logger.Warn("Missing fasting session ID in request")
+		return
+	}
+
+	var updatedData Session
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(userID, &updatedData); err != nil {
+		h.writeSessionError(w, err, id, "Error updating fasting session")
+		return
+	}
+
+	h.Logger.Info("Updated fasting session", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing fasting session ID")
+		h.Logger.Warn("Missing fasting session ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(userID, id); err != nil {
+		h.writeSessionError(w, err, id, "Error deleting fasting session")
+		return
+	}
+
+	h.Logger.Info("Deleted fasting session", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var session Session
+	if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Start(userID, &session); err != nil {
+		h.writeSessionError(w, err, "", "Error starting fast")
+		return
+	}
+
+	h.Logger.Info("Started fast", zap.String("id", session.ID), zap.String("protocol", session.Protocol), zap.Float64("target_hours", session.TargetHours))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Stop(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var request stopRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && err != io.EOF {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	endedAt := time.Now()
+	if request.EndedAt != nil {
+		endedAt = *request.EndedAt
+	}
+
+	session, err := h.Service.Stop(userID, endedAt)
+	if err != nil {
+		h.writeSessionError(w, err, "", "Error stopping fast")
+		return
+	}
+
+	h.Logger.Info("Stopped fast", zap.String("id", session.ID), zap.Timep("ended_at", session.EndedAt))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetCurrent(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	current, err := h.Service.GetCurrent(userID, time.Now())
+	if err != nil {
+		h.writeSessionError(w, err, "", "Error retrieving current fast")
+		return
+	}
+
+	h.Logger.Info("Retrieved current fast", zap.String("id", current.Session.ID), zap.Float64("elapsed_hours", current.ElapsedHours))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(current); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+	if period.To.IsZero() {
+		period.To = weekStart(time.Now(), location).AddDate(0, 0, 7)
+	}
+	if period.From.IsZero() {
+		period.From = period.To.AddDate(0, 0, -7*defaultHistoryWeeks)
+	}
+
+	report, err := h.Service.GetHistory(userID, period, location)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating fasting history")
+		h.Logger.Error("Error calculating fasting history", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Calculated fasting history", zap.Time("from", period.From), zap.Time("to", period.To), zap.Int("fasts", report.Fasts))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeSessionError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Fasting session not found")
+		h.Logger.Warn("Fasting session not found", zap.String("id", id))
+	case ErrNoActiveFast:
+		errors.WriteHTTPError(w, http.StatusNotFound, "No fast in progress")
+		h.Logger.Warn("No fast in progress")
+	case ErrFastInProgress:
+		errors.WriteHTTPError(w, http.StatusConflict, "Another fast is in progress")
+		h.Logger.Warn("Another fast is in progress", zap.String("id", id))
+	case ErrTargetRequired:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Custom fasts need 'target_hours'")
+		h.Logger.Warn("Custom fast without a target", zap.String("id", id))
+	case ErrEndBeforeStart:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Fast must end after it starts")
+		h.Logger.Warn("Fast ends before it starts", zap.String("id", id))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
@@ -0,0 +1,68 @@
+package fasting
+
+import "time"
+
+// Session is a fast following a protocol. TargetHours is fixed by the preset
+// protocols and chosen freely for custom ones. A session without EndedAt is
+// the fast in progress; EndReason records whether it was stopped by hand or
+// broken by a diary entry.
+type Session struct {
+	ID          string     `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID      string     `json:"-" gorm:"type:uuid;not null"`
+	Protocol    string     `json:"protocol" gorm:"not null" validate:"required,oneof=16:8 18:6 omad custom"`
+	TargetHours float64    `json:"target_hours" gorm:"not null" validate:"omitempty,gt=0,lte=168"`
+	StartedAt   time.Time  `json:"started_at" gorm:"not null"`
+	EndedAt     *time.Time `json:"ended_at"`
+	EndReason   string     `json:"end_reason" gorm:"not null;default:''" validate:"-"`
+	Notes       string     `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Session) TableName() string {
+	return "fasting_sessions"
+}
+
+// Protocol is a fasting schedule: TargetHours of fasting followed by an eating
+// window of the rest of the day. Custom protocols leave both to the session.
+type Protocol struct {
+	Code         string   `json:"code"`
+	Name         string   `json:"name"`
+	TargetHours  *float64 `json:"target_hours"`
+	EatingWindow *float64 `json:"eating_window_hours"`
+}
+
+// Current is the live state of the fast in progress.
+type Current struct {
+	Session        *Session  `json:"session"`
+	ElapsedHours   float64   `json:"elapsed_hours"`
+	TargetHours    float64   `json:"target_hours"`
+	RemainingHours float64   `json:"remaining_hours"`
+	Percent        float64   `json:"percent"`
+	TargetAt       time.Time `json:"target_at"`
+	TargetReached  bool      `json:"target_reached"`
+}
+
+// Week summarises the finished fasts started in a local week beginning on
+// Monday. Completed counts the fasts that reached their target. The rates are
+// nil for a week without fasts.
+type Week struct {
+	Start          string   `json:"start"`
+	Fasts          int      `json:"fasts"`
+	Completed      int      `json:"completed"`
+	CompletionRate *float64 `json:"completion_rate"`
+	AverageHours   *float64 `json:"average_hours"`
+	LongestHours   *float64 `json:"longest_hours"`
+}
+
+// History reports fasting week by week over a period, with the same figures
+// over the whole period.
+type History struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Fasts          int       `json:"fasts"`
+	Completed      int       `json:"completed"`
+	CompletionRate *float64  `json:"completion_rate"`
+	AverageHours   *float64  `json:"average_hours"`
+	Weeks          []Week    `json:"weeks"`
+}
@@ -0,0 +1,174 @@
+package fasting
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// Protocol codes. Custom fasts take their target from the session.
+const (
+	Protocol16_8   = "16:8"
+	Protocol18_6   = "18:6"
+	ProtocolOMAD   = "omad"
+	ProtocolCustom = "custom"
+)
+
+// End reasons of a finished fast.
+const (
+	EndReasonManual     = "manual"
+	EndReasonDiaryEntry = "diary_entry"
+)
+
+// presetHours are the fasting hours of the preset protocols.
+var presetHours = map[string]float64{
+	Protocol16_8: 16,
+	Protocol18_6: 18,
+	ProtocolOMAD: 23,
+}
+
+var protocolNames = map[string]string{
+	Protocol16_8:   "16:8",
+	Protocol18_6:   "18:6",
+	ProtocolOMAD:   "One meal a day",
+	ProtocolCustom: "Custom",
+}
+
+var (
+	ErrTargetRequired = errors.New("custom fasts need a target")
+	ErrEndBeforeStart = errors.New("fast ends before it starts")
+	ErrFastInProgress = errors.New("another fast is in progress")
+	ErrNoActiveFast   = errors.New("no fast in progress")
+)
+
+// Protocols lists the supported protocols, presets first.
+func Protocols() []Protocol {
+	protocols := make([]Protocol, 0, len(protocolNames))
+	for _, code := range []string{Protocol16_8, Protocol18_6, ProtocolOMAD, ProtocolCustom} {
+		protocol := Protocol{Code: code, Name: protocolNames[code]}
+		if hours, ok := presetHours[code]; ok {
+			window := 24 - hours
+			protocol.TargetHours = &hours
+			protocol.EatingWindow = &window
+		}
+		protocols = append(protocols, protocol)
+	}
+	return protocols
+}
+
+// resolveTarget sets the target of a preset protocol and checks that a custom
+// fast has one.
+func resolveTarget(session *Session) error {
+	if hours, ok := presetHours[session.Protocol]; ok {
+		session.TargetHours = hours
+		return nil
+	}
+	if session.TargetHours <= 0 {
+		return ErrTargetRequired
+	}
+	return nil
+}
+
+// progress reports how far the fast has come at now.
+func progress(session *Session, now time.Time) *Current {
+	elapsed := now.Sub(session.StartedAt).Hours()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	target := session.StartedAt.Add(time.Duration(session.TargetHours * float64(time.Hour)))
+
+	return &Current{
+		Session:        session,
+		ElapsedHours:   reporting.Round(elapsed, 2),
+		TargetHours:    session.TargetHours,
+		RemainingHours: reporting.Round(math.Max(session.TargetHours-elapsed, 0), 2),
+		Percent:        reporting.Round(math.Min(elapsed/session.TargetHours*100, 100), 1),
+		TargetAt:       target,
+		TargetReached:  !now.Before(target),
+	}
+}
+
+// history groups finished fasts by the local Monday-based week they started in.
+// Every week overlapping [from, to) is reported, including those without
+// fasts; sessions are expected to have started within the period.
+func history(sessions []Session, from, to time.Time, location *time.Location) *History {
+	report := &History{From: from, To: to, Weeks: []Week{}}
+
+	type totals struct {
+		fasts, completed int
+		hours, longest   float64
+	}
+	byWeek := make(map[time.Time]*totals)
+	var overall totals
+
+	for _, session := range sessions {
+		if session.EndedAt == nil {
+			continue
+		}
+		hours := session.EndedAt.Sub(session.StartedAt).Hours()
+		week := weekStart(session.StartedAt, location)
+		t, ok := byWeek[week]
+		if !ok {
+			t = &totals{}
+			byWeek[week] = t
+		}
+		for _, tt := range []*totals{t, &overall} {
+			tt.fasts++
+			tt.hours += hours
+			tt.longest = math.Max(tt.longest, hours)
+			if hours >= session.TargetHours {
+				tt.completed++
+			}
+		}
+	}
+
+	var weeks []time.Time
+	for week := weekStart(from, location); week.Before(to); week = week.AddDate(0, 0, 7) {
+		weeks = append(weeks, week)
+	}
+
+	for _, week := range weeks {
+		entry := Week{Start: week.Format(db.DateLayout)}
+		if t, ok := byWeek[week]; ok {
+			entry.Fasts = t.fasts
+			entry.Completed = t.completed
+			entry.CompletionRate = ratio(t.completed, t.fasts)
+			entry.AverageHours = average(t.hours, t.fasts)
+			longest := reporting.Round(t.longest, 2)
+			entry.LongestHours = &longest
+		}
+		report.Weeks = append(report.Weeks, entry)
+	}
+
+	report.Fasts = overall.fasts
+	report.Completed = overall.completed
+	report.CompletionRate = ratio(overall.completed, overall.fasts)
+	report.AverageHours = average(overall.hours, overall.fasts)
+	return report
+}
+
+// weekStart is local midnight on the Monday of the week containing t.
+func weekStart(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	offset := (int(local.Weekday()) + 6) % 7
+	return time.Date(local.Year(), local.Month(), local.Day()-offset, 0, 0, 0, 0, location)
+}
+
+func ratio(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	value := reporting.Round(float64(part)/float64(whole), 3)
+	return &value
+}
+
+func average(sum float64, count int) *float64 {
+	if count == 0 {
+		return nil
+	}
+	value := reporting.Round(sum/float64(count), 2)
+	return &value
+}
@@ -0,0 +1,123 @@
+package fasting
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResolveTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		session Session
+		want    float64
+		err     error
+	}{
+		{name: "preset overrides the target", session: Session{Protocol: Protocol16_8, TargetHours: 10}, want: 16},
+		{name: "one meal a day", session: Session{Protocol: ProtocolOMAD}, want: 23},
+		{name: "custom", session: Session{Protocol: ProtocolCustom, TargetHours: 36}, want: 36},
+		{name: "custom without target", session: Session{Protocol: ProtocolCustom}, err: ErrTargetRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := resolveTarget(&tt.session); !errors.Is(err, tt.err) {
+				t.Fatalf("resolveTarget() error = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && tt.session.TargetHours != tt.want {
+				t.Errorf("TargetHours = %v, want %v", tt.session.TargetHours, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgress(t *testing.T) {
+	start := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	session := &Session{StartedAt: start, TargetHours: 16}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		elapsed   float64
+		remaining float64
+		percent   float64
+		reached   bool
+	}{
+		{name: "clock behind the start", now: start.Add(-time.Hour), remaining: 16},
+		{name: "under way", now: start.Add(12 * time.Hour), elapsed: 12, remaining: 4, percent: 75},
+		{name: "target reached", now: start.Add(16 * time.Hour), elapsed: 16, percent: 100, reached: true},
+		{name: "past the target", now: start.Add(18*time.Hour + 20*time.Minute), elapsed: 18.33, percent: 100, reached: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := progress(session, tt.now)
+			if got.ElapsedHours != tt.elapsed || got.RemainingHours != tt.remaining ||
+				got.Percent != tt.percent || got.TargetReached != tt.reached {
+				t.Errorf("progress() = %+v, want elapsed %v, remaining %v, percent %v, reached %v",
+					got, tt.elapsed, tt.remaining, tt.percent, tt.reached)
+			}
+			if want := start.Add(16 * time.Hour); !got.TargetAt.Equal(want) {
+				t.Errorf("TargetAt = %v, want %v", got.TargetAt, want)
+			}
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+	ended := func(t time.Time) *time.Time { return &t }
+
+	sessions := []Session{
+		{StartedAt: at(2, 20), EndedAt: ended(at(3, 12)), TargetHours: 16},
+		{StartedAt: at(4, 20), EndedAt: ended(at(5, 10)), TargetHours: 16},
+		{StartedAt: at(6, 20), TargetHours: 16},
+	}
+
+	report := history(sessions, at(2, 0), at(16, 0), time.UTC)
+
+	if report.Fasts != 2 || report.Completed != 1 {
+		t.Errorf("got %d fasts, %d completed, want 2, 1", report.Fasts, report.Completed)
+	}
+	if report.CompletionRate == nil || *report.CompletionRate != 0.5 {
+		t.Errorf("CompletionRate = %v, want 0.5", report.CompletionRate)
+	}
+	if report.AverageHours == nil || *report.AverageHours != 15 {
+		t.Errorf("AverageHours = %v, want 15", report.AverageHours)
+	}
+
+	if len(report.Weeks) != 2 {
+		t.Fatalf("got %d weeks, want 2", len(report.Weeks))
+	}
+	first, second := report.Weeks[0], report.Weeks[1]
+	if first.Start != "2026-03-02" || first.Fasts != 2 || first.LongestHours == nil || *first.LongestHours != 16 {
+		t.Errorf("first week = %+v, want 2 fasts from 2026-03-02, longest 16", first)
+	}
+	if second.Start != "2026-03-09" || second.Fasts != 0 || second.CompletionRate != nil || second.AverageHours != nil {
+		t.Errorf("second week = %+v, want an empty week from 2026-03-09", second)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sundayNight := time.Date(2026, 3, 8, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		location *time.Location
+		want     time.Time
+	}{
+		{location: time.UTC, want: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{location: berlin, want: time.Date(2026, 3, 9, 0, 0, 0, 0, berlin)},
+	}
+
+	for _, tt := range tests {
+		if got := weekStart(sundayNight, tt.location); !got.Equal(tt.want) {
+			t.Errorf("weekStart(%v) = %v, want %v", tt.location, got, tt.want)
+		}
+	}
+}
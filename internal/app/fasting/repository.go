@@ -0,0 +1,96 @@
+package fasting
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+type Repository interface {
+	GetAll(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error)
+	GetByID(userID, id string) (*Session, error)
+	GetOpen(userID string) (*Session, error)
+	GetLatest(userID string) (*Session, error)
+	GetFinished(userID string, period reporting.Range) ([]Session, error)
+	Create(session *Session) error
+	Update(session *Session) error
+	Delete(userID, id string) error
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error) {
+	var sessions []Session
+	var total int64
+
+	query := r.db.Model(&Session{}).Where("user_id = ?", userID)
+	if !period.From.IsZero() {
+		query = query.Where("started_at >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where("started_at < ?", period.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("started_at DESC").Limit(limit).Offset(offset).Find(&sessions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return sessions, total, nil
+}
+
+func (r *repositoryImpl) GetByID(userID, id string) (*Session, error) {
+	var session Session
+	if err := r.db.First(&session, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetOpen returns the user's fast in progress, or gorm.ErrRecordNotFound.
+func (r *repositoryImpl) GetOpen(userID string) (*Session, error) {
+	var session Session
+	if err := r.db.Where("user_id = ? AND ended_at IS NULL", userID).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetLatest returns the last fast the user started, or gorm.ErrRecordNotFound.
+func (r *repositoryImpl) GetLatest(userID string) (*Session, error) {
+	var session Session
+	if err := r.db.Where("user_id = ?", userID).Order("started_at DESC").First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *repositoryImpl) GetFinished(userID string, period reporting.Range) ([]Session, error) {
+	var sessions []Session
+	err := r.db.
+		Where("user_id = ? AND ended_at IS NOT NULL AND started_at >= ? AND started_at < ?", userID, period.From, period.To).
+		Order("started_at").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *repositoryImpl) Create(session *Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *repositoryImpl) Update(session *Session) error {
+	return r.db.Save(session).Error
+}
+
+func (r *repositoryImpl) Delete(userID, id string) error {
+	return r.db.Delete(&Session{}, "id = ? AND user_id = ?", id, userID).Error
+}
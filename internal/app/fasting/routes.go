@@ -0,0 +1,21 @@
+package fasting
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/protocols", h.GetProtocols)
+	r.Get("/current", h.GetCurrent)
+	r.Post("/start", h.Start)
+	r.Post("/stop", h.Stop)
+	r.Get("/history", h.GetHistory)
+
+	r.Get("/sessions", h.GetSessions)
+	r.Post("/sessions", h.CreateSession)
+	r.Get("/sessions/{id}", h.GetSessionByID)
+	r.Put("/sessions/{id}", h.UpdateSession)
+	r.Delete("/sessions/{id}", h.DeleteSession)
+
+	return r
+}
@@ -0,0 +1,256 @@
+package fasting
+
+import (
+	"errors"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetAll(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error)
+	GetByID(userID, id string) (*Session, error)
+	Create(userID string, session *Session) error
+	Update(userID string, session *Session) error
+	Delete(userID, id string) error
+
+	// Start begins a fast now unless StartedAt is set.
+	Start(userID string, session *Session) error
+	// Stop ends the user's fast in progress at the given time.
+	Stop(userID string, at time.Time) (*Session, error)
+	GetCurrent(userID string, now time.Time) (*Current, error)
+	GetHistory(userID string, period reporting.Range, location *time.Location) (*History, error)
+
+	// EntriesLogged breaks the user's fast in progress at the first entry eaten
+	// after it started.
+	EntriesLogged(userID string, entries []diary.Entry) error
+	// EntriesRemoved moves the end of the user's latest fast, when one of the
+	// entries broke it, to the next entry eaten after it started, or reopens it.
+	EntriesRemoved(userID string, entries []diary.Entry) error
+}
+
+type serviceImpl struct {
+	repo  Repository
+	diary diary.Service
+}
+
+func NewService(repo Repository, diary diary.Service) Service {
+	return &serviceImpl{repo: repo, diary: diary}
+}
+
+func (s *serviceImpl) GetAll(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error) {
+	return s.repo.GetAll(userID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(userID, id string) (*Session, error) {
+	return s.repo.GetByID(userID, id)
+}
+
+func (s *serviceImpl) Create(userID string, session *Session) error {
+	session.UserID = userID
+	if session.StartedAt.IsZero() {
+		session.StartedAt = time.Now()
+	}
+	if err := s.prepare(session, ""); err != nil {
+		return err
+	}
+	return s.create(session)
+}
+
+func (s *serviceImpl) Update(userID string, session *Session) error {
+	existingSession, err := s.repo.GetByID(userID, session.ID)
+	if err != nil {
+		return err
+	}
+
+	session.UserID = userID
+	if session.StartedAt.IsZero() {
+		session.StartedAt = existingSession.StartedAt
+	}
+	if err := s.prepare(session, existingSession.EndReason); err != nil {
+		return err
+	}
+	if session.EndedAt == nil && existingSession.EndedAt != nil {
+		if err := s.checkNoneOpen(userID); err != nil {
+			return err
+		}
+	}
+
+	session.CreatedAt = existingSession.CreatedAt
+	if err := s.repo.Update(session); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrFastInProgress
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) Delete(userID, id string) error {
+	if _, err := s.repo.GetByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(userID, id)
+}
+
+func (s *serviceImpl) Start(userID string, session *Session) error {
+	session.EndedAt = nil
+	return s.Create(userID, session)
+}
+
+func (s *serviceImpl) Stop(userID string, at time.Time) (*Session, error) {
+	session, err := s.open(userID)
+	if err != nil {
+		return nil, err
+	}
+	if at.Before(session.StartedAt) {
+		return nil, ErrEndBeforeStart
+	}
+
+	session.EndedAt = &at
+	session.EndReason = EndReasonManual
+	if err := s.repo.Update(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *serviceImpl) GetCurrent(userID string, now time.Time) (*Current, error) {
+	session, err := s.open(userID)
+	if err != nil {
+		return nil, err
+	}
+	return progress(session, now), nil
+}
+
+func (s *serviceImpl) GetHistory(userID string, period reporting.Range, location *time.Location) (*History, error) {
+	sessions, err := s.repo.GetFinished(userID, period)
+	if err != nil {
+		return nil, err
+	}
+	return history(sessions, period.From, period.To, location), nil
+}
+
+func (s *serviceImpl) EntriesLogged(userID string, entries []diary.Entry) error {
+	session, err := s.open(userID)
+	if errors.Is(err, ErrNoActiveFast) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var brokenAt *time.Time
+	for i := range entries {
+		consumedAt := entries[i].ConsumedAt
+		if consumedAt.Before(session.StartedAt) {
+			continue
+		}
+		if brokenAt == nil || consumedAt.Before(*brokenAt) {
+			brokenAt = &consumedAt
+		}
+	}
+	if brokenAt == nil {
+		return nil
+	}
+
+	session.EndedAt = brokenAt
+	session.EndReason = EndReasonDiaryEntry
+	return s.repo.Update(session)
+}
+
+func (s *serviceImpl) EntriesRemoved(userID string, entries []diary.Entry) error {
+	session, err := s.repo.GetLatest(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if session.EndReason != EndReasonDiaryEntry || !brokenBy(session, entries) {
+		return nil
+	}
+
+	filter := diary.EntryFilter{Range: reporting.Range{From: session.StartedAt}}
+	next, _, err := s.diary.GetEntries(userID, filter, 1, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(next) > 0 {
+		session.EndedAt = &next[0].ConsumedAt
+	} else {
+		session.EndedAt = nil
+		session.EndReason = ""
+	}
+	return s.repo.Update(session)
+}
+
+// brokenBy reports whether one of the entries ended the session.
+func brokenBy(session *Session, entries []diary.Entry) bool {
+	for _, entry := range entries {
+		if session.EndedAt != nil && entry.ConsumedAt.Equal(*session.EndedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepare resolves the target and end reason of a session and checks its
+// times. A session keeps the reason it was ended for; one ended by hand is
+// recorded as such.
+func (s *serviceImpl) prepare(session *Session, endReason string) error {
+	if err := resolveTarget(session); err != nil {
+		return err
+	}
+
+	session.EndReason = ""
+	if session.EndedAt == nil {
+		return nil
+	}
+	if session.EndedAt.Before(session.StartedAt) {
+		return ErrEndBeforeStart
+	}
+	session.EndReason = endReason
+	if session.EndReason == "" {
+		session.EndReason = EndReasonManual
+	}
+	return nil
+}
+
+func (s *serviceImpl) create(session *Session) error {
+	if session.EndedAt == nil {
+		if err := s.checkNoneOpen(session.UserID); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.Create(session); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrFastInProgress
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) open(userID string) (*Session, error) {
+	session, err := s.repo.GetOpen(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoActiveFast
+	}
+	return session, err
+}
+
+func (s *serviceImpl) checkNoneOpen(userID string) error {
+	_, err := s.open(userID)
+	switch {
+	case err == nil:
+		return ErrFastInProgress
+	case errors.Is(err, ErrNoActiveFast):
+		return nil
+	default:
+		return err
+	}
+}
@@ -0,0 +1,130 @@
+package fasting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"gorm.io/gorm"
+)
+
+// fakeRepository holds a single fast as both the open and the latest one.
+type fakeRepository struct {
+	Repository
+	session *Session
+}
+
+func (r *fakeRepository) GetOpen(userID string) (*Session, error) {
+	if r.session == nil || r.session.EndedAt != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *r.session
+	return &copied, nil
+}
+
+func (r *fakeRepository) GetLatest(userID string) (*Session, error) {
+	if r.session == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *r.session
+	return &copied, nil
+}
+
+func (r *fakeRepository) Update(session *Session) error {
+	r.session = session
+	return nil
+}
+
+// fakeDiary returns the entries eaten at or after the filter's start.
+type fakeDiary struct {
+	diary.Service
+	entries []diary.Entry
+}
+
+func (d *fakeDiary) GetEntries(userID string, filter diary.EntryFilter, limit, offset int) ([]diary.Entry, int64, error) {
+	var entries []diary.Entry
+	for _, entry := range d.entries {
+		if !entry.ConsumedAt.Before(filter.From) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, int64(len(entries)), nil
+}
+
+func TestEntriesLogged(t *testing.T) {
+	start := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	entries := []diary.Entry{
+		{ConsumedAt: start.Add(-time.Hour)},
+		{ConsumedAt: start.Add(16 * time.Hour)},
+		{ConsumedAt: start.Add(13 * time.Hour)},
+	}
+
+	repo := &fakeRepository{session: &Session{StartedAt: start, TargetHours: 16}}
+	if err := NewService(repo, &fakeDiary{}).EntriesLogged("user", entries); err != nil {
+		t.Fatal(err)
+	}
+	if repo.session.EndedAt == nil || !repo.session.EndedAt.Equal(start.Add(13*time.Hour)) {
+		t.Errorf("EndedAt = %v, want the first entry after the start", repo.session.EndedAt)
+	}
+	if repo.session.EndReason != EndReasonDiaryEntry {
+		t.Errorf("EndReason = %q, want %q", repo.session.EndReason, EndReasonDiaryEntry)
+	}
+
+	repo = &fakeRepository{}
+	if err := NewService(repo, &fakeDiary{}).EntriesLogged("user", entries); err != nil {
+		t.Errorf("EntriesLogged() without a fast error = %v", err)
+	}
+}
+
+func TestEntriesRemoved(t *testing.T) {
+	start := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	brokenAt := start.Add(13 * time.Hour)
+	next := start.Add(15 * time.Hour)
+
+	tests := []struct {
+		name      string
+		reason    string
+		removed   time.Time
+		remaining []diary.Entry
+		endedAt   *time.Time
+		endReason string
+	}{
+		{
+			name: "moves the end to the next entry", reason: EndReasonDiaryEntry, removed: brokenAt,
+			remaining: []diary.Entry{{ConsumedAt: start.Add(-time.Hour)}, {ConsumedAt: next}},
+			endedAt:   &next, endReason: EndReasonDiaryEntry,
+		},
+		{
+			name: "reopens the fast without later entries", reason: EndReasonDiaryEntry, removed: brokenAt,
+		},
+		{
+			name: "keeps the end for another entry", reason: EndReasonDiaryEntry, removed: next,
+			endedAt: &brokenAt, endReason: EndReasonDiaryEntry,
+		},
+		{
+			name: "keeps a fast stopped by hand", reason: EndReasonManual, removed: brokenAt,
+			endedAt: &brokenAt, endReason: EndReasonManual,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ended := brokenAt
+			repo := &fakeRepository{session: &Session{StartedAt: start, TargetHours: 16, EndedAt: &ended, EndReason: tt.reason}}
+			service := NewService(repo, &fakeDiary{entries: tt.remaining})
+
+			if err := service.EntriesRemoved("user", []diary.Entry{{ConsumedAt: tt.removed}}); err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.endedAt == nil && repo.session.EndedAt != nil:
+				t.Errorf("EndedAt = %v, want an open fast", *repo.session.EndedAt)
+			case tt.endedAt != nil && (repo.session.EndedAt == nil || !repo.session.EndedAt.Equal(*tt.endedAt)):
+				t.Errorf("EndedAt = %v, want %v", repo.session.EndedAt, *tt.endedAt)
+			}
+			if repo.session.EndReason != tt.endReason {
+				t.Errorf("EndReason = %q, want %q", repo.session.EndReason, tt.endReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package food
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidBarcodeFormat = errors.New("barcode must consist of 8, 12, 13 or 14 digits")
+	ErrInvalidCheckDigit    = errors.New("barcode check digit is invalid")
+)
+
+// gtinLength is the length barcodes are stored with; shorter codes are left-padded with zeros.
+const gtinLength = 14
+
+// NormalizeBarcode validates an EAN-8, UPC-A, EAN-13 or GTIN-14 barcode and returns it as GTIN-14.
+// Spaces and hyphens are ignored.
+func NormalizeBarcode(code string) (string, error) {
+	code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return "", ErrInvalidBarcodeFormat
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return "", ErrInvalidBarcodeFormat
+		}
+	}
+
+	if checkDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return "", ErrInvalidCheckDigit
+	}
+
+	return strings.Repeat("0", gtinLength-len(code)) + code, nil
+}
+
+// checkDigit computes the GS1 modulo-10 check digit of a code without its check digit.
+// Weights alternate 3, 1, 3, ... starting from the rightmost digit.
+func checkDigit(payload string) byte {
+	sum := 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		digit := int(payload[i] - '0')
+		if (len(payload)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
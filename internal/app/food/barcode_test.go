@@ -0,0 +1,60 @@
+package food
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+		err  error
+	}{
+		{name: "EAN-8", code: "96385074", want: "00000096385074"},
+		{name: "UPC-A", code: "036000291452", want: "00036000291452"},
+		{name: "EAN-13", code: "4006381333931", want: "04006381333931"},
+		{name: "GTIN-14", code: "10012345678902", want: "10012345678902"},
+		{name: "check digit zero", code: "0012345678905", want: "00012345678905"},
+		{name: "spaces and hyphens", code: "400-6381 333931", want: "04006381333931"},
+		{name: "wrong check digit", code: "4006381333932", err: ErrInvalidCheckDigit},
+		{name: "transposed digits", code: "4006381339331", err: ErrInvalidCheckDigit},
+		{name: "too short", code: "1234567", err: ErrInvalidBarcodeFormat},
+		{name: "unsupported length", code: "12345678901", err: ErrInvalidBarcodeFormat},
+		{name: "too long", code: "123456789012345", err: ErrInvalidBarcodeFormat},
+		{name: "letters", code: "40063813339X1", err: ErrInvalidBarcodeFormat},
+		{name: "empty", code: "", err: ErrInvalidBarcodeFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBarcode(tt.code)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NormalizeBarcode(%q) error = %v, want %v", tt.code, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeBarcode(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    byte
+	}{
+		{payload: "9638507", want: '4'},
+		{payload: "03600029145", want: '2'},
+		{payload: "400638133393", want: '1'},
+		{payload: "1001234567890", want: '2'},
+		{payload: "000000000000", want: '0'},
+	}
+
+	for _, tt := range tests {
+		if got := checkDigit(tt.payload); got != tt.want {
+			t.Errorf("checkDigit(%q) = %c, want %c", tt.payload, got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ func NewHandlerFactory(db *gorm.DB, log *zap.Logger) *Handler {
 	repo := NewRepository(db)
 	service := NewService(repo)
 	validator := validator.New()
+	RegisterValidations(validator)
 	foodLog := log.Named("FoodHandler")
 
 	return NewHandler(service, validator, foodLog)
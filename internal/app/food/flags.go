@@ -0,0 +1,127 @@
+package food
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// The 14 allergens that must be declared under EU Regulation No 1169/2011, Annex II.
+const (
+	AllergenGluten      = "gluten"
+	AllergenCrustaceans = "crustaceans"
+	AllergenEggs        = "eggs"
+	AllergenFish        = "fish"
+	AllergenPeanuts     = "peanuts"
+	AllergenSoybeans    = "soybeans"
+	AllergenMilk        = "milk"
+	AllergenNuts        = "nuts"
+	AllergenCelery      = "celery"
+	AllergenMustard     = "mustard"
+	AllergenSesame      = "sesame"
+	AllergenSulphites   = "sulphites"
+	AllergenLupin       = "lupin"
+	AllergenMolluscs    = "molluscs"
+)
+
+// Dietary flags.
+const (
+	DietVegan      = "vegan"
+	DietVegetarian = "vegetarian"
+	DietGlutenFree = "gluten_free"
+	DietHalal      = "halal"
+	DietKosher     = "kosher"
+)
+
+var allergens = map[string]bool{
+	AllergenGluten: true, AllergenCrustaceans: true, AllergenEggs: true, AllergenFish: true,
+	AllergenPeanuts: true, AllergenSoybeans: true, AllergenMilk: true, AllergenNuts: true,
+	AllergenCelery: true, AllergenMustard: true, AllergenSesame: true, AllergenSulphites: true,
+	AllergenLupin: true, AllergenMolluscs: true,
+}
+
+var diets = map[string]bool{
+	DietVegan: true, DietVegetarian: true, DietGlutenFree: true, DietHalal: true, DietKosher: true,
+}
+
+// dietExclusions lists the allergens a food cannot contain while carrying a dietary flag.
+var dietExclusions = map[string][]string{
+	DietVegan:      {AllergenMilk, AllergenEggs, AllergenFish, AllergenCrustaceans, AllergenMolluscs},
+	DietVegetarian: {AllergenFish, AllergenCrustaceans, AllergenMolluscs},
+	DietGlutenFree: {AllergenGluten},
+}
+
+func IsAllergen(value string) bool {
+	return allergens[value]
+}
+
+func IsDiet(value string) bool {
+	return diets[value]
+}
+
+// FlagConflictError is returned when a dietary flag contradicts a declared allergen.
+type FlagConflictError struct {
+	Diet     string
+	Allergen string
+}
+
+func (e *FlagConflictError) Error() string {
+	return fmt.Sprintf("diet '%s' conflicts with allergen '%s'", e.Diet, e.Allergen)
+}
+
+// normalizeFlags marks the allergens as verified when they were given, sorts the flags,
+// adds vegetarian to vegan foods and checks that no dietary flag contradicts a declared allergen.
+func normalizeFlags(food *Food) error {
+	food.AllergensVerified = food.Allergens != nil
+	if food.Allergens == nil {
+		food.Allergens = []string{}
+	}
+	if food.Diets == nil {
+		food.Diets = []string{}
+	}
+
+	hasDiet := make(map[string]bool, len(food.Diets))
+	for _, diet := range food.Diets {
+		hasDiet[diet] = true
+	}
+	if hasDiet[DietVegan] && !hasDiet[DietVegetarian] {
+		food.Diets = append(food.Diets, DietVegetarian)
+		hasDiet[DietVegetarian] = true
+	}
+
+	hasAllergen := make(map[string]bool, len(food.Allergens))
+	for _, allergen := range food.Allergens {
+		hasAllergen[allergen] = true
+	}
+	for _, diet := range []string{DietVegan, DietVegetarian, DietGlutenFree} {
+		if !hasDiet[diet] {
+			continue
+		}
+		for _, allergen := range dietExclusions[diet] {
+			if hasAllergen[allergen] {
+				return &FlagConflictError{Diet: diet, Allergen: allergen}
+			}
+		}
+	}
+
+	sort.Strings(food.Allergens)
+	sort.Strings(food.Diets)
+	return nil
+}
+
+// RegisterValidations adds the "allergen" and "diet" tags to a validator.
+func RegisterValidations(v *validator.Validate) {
+	must(v.RegisterValidation("allergen", func(fl validator.FieldLevel) bool {
+		return IsAllergen(fl.Field().String())
+	}))
+	must(v.RegisterValidation("diet", func(fl validator.FieldLevel) bool {
+		return IsDiet(fl.Field().String())
+	}))
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -2,6 +2,7 @@ package food
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
@@ -9,6 +10,7 @@ import (
 	"gorm.io/gorm"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type Handler struct {
@@ -49,7 +51,46 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	foods, total, err := h.Service.GetAll(limit, offset)
+	filter := Filter{
+		Query:   r.URL.Query().Get("q"),
+		BrandID: r.URL.Query().Get("brand_id"),
+	}
+	if len(filter.Query) > 100 {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'q' parameter")
+		h.Logger.Warn("Search query too long", zap.Int("length", len(filter.Query)))
+		return
+	}
+	if filter.BrandID != "" {
+		if err := h.Validator.Var(filter.BrandID, "uuid"); err != nil {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'brand_id' parameter")
+			h.Logger.Warn("Invalid 'brand_id' parameter", zap.String("brand_id", filter.BrandID))
+			return
+		}
+	}
+
+	if a := r.URL.Query().Get("exclude_allergens"); a != "" {
+		filter.ExcludeAllergens = strings.Split(a, ",")
+		for _, allergen := range filter.ExcludeAllergens {
+			if !IsAllergen(allergen) {
+				errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("Invalid 'exclude_allergens' parameter: unknown allergen '%s'", allergen))
+				h.Logger.Warn("Invalid 'exclude_allergens' parameter", zap.String("exclude_allergens", a))
+				return
+			}
+		}
+	}
+
+	if d := r.URL.Query().Get("diet"); d != "" {
+		filter.Diets = strings.Split(d, ",")
+		for _, diet := range filter.Diets {
+			if !IsDiet(diet) {
+				errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("Invalid 'diet' parameter: unknown diet '%s'", diet))
+				h.Logger.Warn("Invalid 'diet' parameter", zap.String("diet", d))
+				return
+			}
+		}
+	}
+
+	foods, total, err := h.Service.GetAll(filter, limit, offset)
 	if err != nil {
 		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving foods")
 		h.Logger.Error("Error retrieving foods", zap.Error(err))
@@ -64,7 +105,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 		"returned": len(foods),
 	}
 
-	h.Logger.Info("Retrieved foods", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(foods)))
+	h.Logger.Info("Retrieved foods", zap.String("q", filter.Query), zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(foods)))
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		h.Logger.Error("Failed to encode response", zap.Error(err))
@@ -86,6 +127,14 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.Service.Create(&food); err != nil {
+		if h.writeBarcodeError(w, err, food.Barcode) || h.writeFlagError(w, err) {
+			return
+		}
+		if err == ErrBrandNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Brand not found")
+			h.Logger.Warn("Brand not found", zap.Stringp("brand_id", food.BrandID))
+			return
+		}
 		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating food")
 		h.Logger.Error("Error creating food", zap.Error(err))
 		return
@@ -106,7 +155,17 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	food, err := h.Service.GetByID(id)
+	getFood := h.Service.GetByID
+	if include := r.URL.Query().Get("include"); include != "" {
+		if include != "nutrients" {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'include' parameter")
+			h.Logger.Warn("Invalid 'include' parameter", zap.String("include", include))
+			return
+		}
+		getFood = h.Service.GetByIDWithNutrients
+	}
+
+	food, err := getFood(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
@@ -125,6 +184,36 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handler) GetByBarcode(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if code == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing barcode")
+		h.Logger.Warn("Missing barcode in request")
+		return
+	}
+
+	food, err := h.Service.GetByBarcode(code)
+	if err != nil {
+		if h.writeBarcodeError(w, err, &code) {
+			return
+		}
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("barcode", code))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving food")
+		h.Logger.Error("Error retrieving food", zap.String("barcode", code), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved food by barcode", zap.String("id", food.ID), zap.String("barcode", code))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(food); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
@@ -153,6 +242,14 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 			h.Logger.Warn("Food not found", zap.String("id", id))
 			return
 		}
+		if h.writeBarcodeError(w, err, updatedData.Barcode) || h.writeFlagError(w, err) {
+			return
+		}
+		if err == ErrBrandNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Brand not found")
+			h.Logger.Warn("Brand not found", zap.Stringp("brand_id", updatedData.BrandID))
+			return
+		}
 		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating food")
 		h.Logger.Error("Error updating food", zap.String("id", id), zap.Error(err))
 		return
@@ -178,6 +275,11 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 			h.Logger.Warn("Food not found", zap.String("id", id))
 			return
 		}
+		if err == ErrFoodInUse {
+			errors.WriteHTTPError(w, http.StatusConflict, "Food is still in use")
+			h.Logger.Warn("Food is still in use", zap.String("id", id))
+			return
+		}
 		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting food")
 		h.Logger.Error("Error deleting food", zap.String("id", id), zap.Error(err))
 		return
@@ -186,3 +288,182 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	h.Logger.Info("Deleted food", zap.String("id", id))
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *Handler) GetPortions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food ID")
+		h.Logger.Warn("Missing food ID in request")
+		return
+	}
+
+	portions, err := h.Service.GetPortions(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving portions")
+		h.Logger.Error("Error retrieving portions", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved portions", zap.String("id", id), zap.Int("returned", len(portions)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(portions); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreatePortion(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food ID")
+		h.Logger.Warn("Missing food ID in request")
+		return
+	}
+
+	var portion Portion
+	if err := json.NewDecoder(r.Body).Decode(&portion); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(portion); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	portion.FoodID = id
+	if err := h.Service.CreatePortion(&portion); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("id", id))
+		case gorm.ErrDuplicatedKey:
+			errors.WriteHTTPError(w, http.StatusConflict, "Portion with this name already exists")
+			h.Logger.Warn("Duplicate portion name", zap.String("id", id), zap.String("name", portion.Name))
+		case ErrReservedUnit:
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Portion name is a measurement unit")
+			h.Logger.Warn("Portion named after a unit", zap.String("id", id), zap.String("name", portion.Name))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating portion")
+			h.Logger.Error("Error creating portion", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Created new portion", zap.String("id", portion.ID), zap.String("food_id", id), zap.String("name", portion.Name))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(portion); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeletePortion(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	portionID := chi.URLParam(r, "portionId")
+	if id == "" || portionID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food or portion ID")
+		h.Logger.Warn("Missing food or portion ID in request")
+		return
+	}
+
+	if err := h.Service.DeletePortion(id, portionID); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("id", id))
+		case ErrPortionNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Portion not found")
+			h.Logger.Warn("Portion not found", zap.String("id", id), zap.String("portion_id", portionID))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting portion")
+			h.Logger.Error("Error deleting portion", zap.String("id", id), zap.String("portion_id", portionID), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Deleted portion", zap.String("id", id), zap.String("portion_id", portionID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetNutrition(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food ID")
+		h.Logger.Warn("Missing food ID in request")
+		return
+	}
+
+	amount := 100.0
+	if a := r.URL.Query().Get("amount"); a != "" {
+		if parsed, err := strconv.ParseFloat(a, 64); err == nil && parsed > 0 {
+			amount = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'amount' parameter")
+			h.Logger.Warn("Invalid 'amount' parameter", zap.String("amount", a))
+			return
+		}
+	}
+
+	unit := r.URL.Query().Get("unit")
+	if unit == "" {
+		unit = "g"
+	}
+
+	serving, err := h.Service.NutritionFor(id, amount, unit)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("id", id))
+		case ErrUnknownUnit:
+			errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("Unknown unit '%s'", unit))
+			h.Logger.Warn("Unknown unit", zap.String("id", id), zap.String("unit", unit))
+		case ErrDensityRequired:
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Food has no density, volume units cannot be used")
+			h.Logger.Warn("Volume unit without density", zap.String("id", id), zap.String("unit", unit))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating nutrition")
+			h.Logger.Error("Error calculating nutrition", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Calculated nutrition", zap.String("id", id), zap.Float64("amount", amount), zap.String("unit", unit))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(serving); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+// writeBarcodeError writes the response for barcode related errors and reports whether err was one.
+func (h *Handler) writeBarcodeError(w http.ResponseWriter, err error, barcode *string) bool {
+	switch err {
+	case ErrInvalidBarcodeFormat:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid barcode: must consist of 8, 12, 13 or 14 digits (EAN-8, UPC-A, EAN-13 or GTIN-14)")
+	case ErrInvalidCheckDigit:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid barcode: check digit does not match")
+	case ErrDuplicateBarcode:
+		errors.WriteHTTPError(w, http.StatusConflict, "Barcode is already assigned to another food")
+	default:
+		return false
+	}
+	h.Logger.Warn("Rejected barcode", zap.Stringp("barcode", barcode), zap.Error(err))
+	return true
+}
+
+// writeFlagError writes the response for contradicting dietary flags and reports whether err was one.
+func (h *Handler) writeFlagError(w http.ResponseWriter, err error) bool {
+	conflict, ok := err.(*FlagConflictError)
+	if !ok {
+		return false
+	}
+	errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("Diet '%s' conflicts with allergen '%s'", conflict.Diet, conflict.Allergen))
+	h.Logger.Warn("Conflicting dietary flags", zap.String("diet", conflict.Diet), zap.String("allergen", conflict.Allergen))
+	return true
+}
@@ -1,10 +1,47 @@
 package food
 
-import "time"
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/nutrient"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+)
 
 type Food struct {
+	ID                string                  `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Name              string                  `json:"name" gorm:"not null" validate:"required,min=1,max=50"`
+	Nutrition         Nutrition               `json:"nutrition" gorm:"embedded"`
+	Nutrients         []nutrient.FoodNutrient `json:"nutrients,omitempty" gorm:"foreignKey:FoodID" validate:"-"`
+	Density           *float64                `json:"density_g_per_ml,omitempty" gorm:"column:density_g_per_ml" validate:"omitempty,gt=0,lte=25"`
+	WaterFactor       *float64                `json:"water_factor,omitempty" validate:"omitempty,gt=0,lte=1"`
+	Barcode           *string                 `json:"barcode,omitempty" gorm:"uniqueIndex" validate:"omitempty,max=20"`
+	BrandID           *string                 `json:"brand_id" gorm:"type:uuid" validate:"omitempty,uuid"`
+	Allergens         db.StringArray          `json:"allergens" gorm:"type:text[];not null;default:'{}'" validate:"unique,dive,allergen"`
+	AllergensVerified bool                    `json:"allergens_verified" gorm:"not null"`
+	Diets             db.StringArray          `json:"diets" gorm:"type:text[];not null;default:'{}'" validate:"unique,dive,diet"`
+	CreatedAt         time.Time               `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time               `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Portion is a named serving of a food, e.g. "slice" or "medium", with its weight in grams.
+type Portion struct {
 	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	FoodID    string    `json:"food_id" gorm:"type:uuid;not null"`
 	Name      string    `json:"name" gorm:"not null" validate:"required,min=1,max=50"`
+	Grams     float64   `json:"grams" gorm:"not null" validate:"required,gt=0,lte=10000"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
+
+func (Portion) TableName() string {
+	return "food_portions"
+}
+
+// Serving is the nutrition of a given amount of a food.
+type Serving struct {
+	FoodID    string    `json:"food_id"`
+	Amount    float64   `json:"amount"`
+	Unit      string    `json:"unit"`
+	Grams     float64   `json:"grams"`
+	Nutrition Nutrition `json:"nutrition"`
+}
@@ -0,0 +1,77 @@
+package food
+
+import (
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// Nutrition holds the nutrition facts of a food per 100 g of edible portion.
+// Sodium is stored in milligrams, energy in both kcal and kJ, everything else in grams.
+type Nutrition struct {
+	EnergyKcal    float64 `json:"energy_kcal" gorm:"not null;default:0" validate:"gte=0,lte=900"`
+	EnergyKJ      float64 `json:"energy_kj" gorm:"column:energy_kj;not null;default:0" validate:"gte=0,lte=3800"`
+	ProteinG      float64 `json:"protein_g" gorm:"not null;default:0" validate:"gte=0,lte=100"`
+	FatG          float64 `json:"fat_g" gorm:"not null;default:0" validate:"gte=0,lte=100"`
+	SaturatedFatG float64 `json:"saturated_fat_g" gorm:"not null;default:0" validate:"gte=0,lte=100,ltefield=FatG"`
+	CarbohydrateG float64 `json:"carbohydrate_g" gorm:"not null;default:0" validate:"gte=0,lte=100"`
+	SugarG        float64 `json:"sugar_g" gorm:"not null;default:0" validate:"gte=0,lte=100,ltefield=CarbohydrateG"`
+	FiberG        float64 `json:"fiber_g" gorm:"not null;default:0" validate:"gte=0,lte=100"`
+	SodiumMg      float64 `json:"sodium_mg" gorm:"not null;default:0" validate:"gte=0,lte=40000"`
+}
+
+// KJPerKcal is the thermochemical calorie conversion factor.
+const KJPerKcal = 4.184
+
+// Normalize fills in whichever energy unit is missing from the other one.
+func (n *Nutrition) Normalize() {
+	switch {
+	case n.EnergyKcal == 0 && n.EnergyKJ > 0:
+		n.EnergyKcal = reporting.Round(n.EnergyKJ/KJPerKcal, 1)
+	case n.EnergyKJ == 0 && n.EnergyKcal > 0:
+		n.EnergyKJ = reporting.Round(n.EnergyKcal*KJPerKcal, 1)
+	}
+}
+
+// Scale returns the nutrition multiplied by factor, e.g. grams/100 for a serving.
+func (n Nutrition) Scale(factor float64) Nutrition {
+	return Nutrition{
+		EnergyKcal:    n.EnergyKcal * factor,
+		EnergyKJ:      n.EnergyKJ * factor,
+		ProteinG:      n.ProteinG * factor,
+		FatG:          n.FatG * factor,
+		SaturatedFatG: n.SaturatedFatG * factor,
+		CarbohydrateG: n.CarbohydrateG * factor,
+		SugarG:        n.SugarG * factor,
+		FiberG:        n.FiberG * factor,
+		SodiumMg:      n.SodiumMg * factor,
+	}
+}
+
+// Add returns the sum of both nutrition values.
+func (n Nutrition) Add(other Nutrition) Nutrition {
+	return Nutrition{
+		EnergyKcal:    n.EnergyKcal + other.EnergyKcal,
+		EnergyKJ:      n.EnergyKJ + other.EnergyKJ,
+		ProteinG:      n.ProteinG + other.ProteinG,
+		FatG:          n.FatG + other.FatG,
+		SaturatedFatG: n.SaturatedFatG + other.SaturatedFatG,
+		CarbohydrateG: n.CarbohydrateG + other.CarbohydrateG,
+		SugarG:        n.SugarG + other.SugarG,
+		FiberG:        n.FiberG + other.FiberG,
+		SodiumMg:      n.SodiumMg + other.SodiumMg,
+	}
+}
+
+// Round returns the nutrition with every value rounded to the given number of decimal places.
+func (n Nutrition) Round(places int) Nutrition {
+	return Nutrition{
+		EnergyKcal:    reporting.Round(n.EnergyKcal, places),
+		EnergyKJ:      reporting.Round(n.EnergyKJ, places),
+		ProteinG:      reporting.Round(n.ProteinG, places),
+		FatG:          reporting.Round(n.FatG, places),
+		SaturatedFatG: reporting.Round(n.SaturatedFatG, places),
+		CarbohydrateG: reporting.Round(n.CarbohydrateG, places),
+		SugarG:        reporting.Round(n.SugarG, places),
+		FiberG:        reporting.Round(n.FiberG, places),
+		SodiumMg:      reporting.Round(n.SodiumMg, places),
+	}
+}
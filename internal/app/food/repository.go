@@ -1,15 +1,39 @@
 package food
 
 import (
+	"strings"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Filter narrows down the foods returned by Repository.GetAll.
+type Filter struct {
+	// Query is a free-text search term, matched accent-insensitively against the
+	// name both as full text and by trigram word similarity.
+	Query string
+	// BrandID restricts the result to foods of a single brand.
+	BrandID string
+	// ExcludeAllergens drops foods containing any of the allergens, and foods whose allergens are unknown.
+	ExcludeAllergens []string
+	// Diets keeps only foods carrying all of the dietary flags.
+	Diets []string
+}
+
 type Repository interface {
-	GetAll(limit, offset int) ([]Food, int64, error)
+	GetAll(filter Filter, limit, offset int) ([]Food, int64, error)
 	GetByID(id string) (*Food, error)
+	GetByIDWithNutrients(id string) (*Food, error)
+	GetByBarcode(barcode string) (*Food, error)
+	BrandExists(brandID string) (bool, error)
 	Create(food *Food) error
 	Update(food *Food) error
 	Delete(id string) error
+
+	GetPortions(foodID string) ([]Portion, error)
+	CreatePortion(portion *Portion) error
+	DeletePortion(foodID, portionID string) (int64, error)
 }
 
 type repository struct {
@@ -20,15 +44,44 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{DB: db}
 }
 
-func (r *repository) GetAll(limit, offset int) ([]Food, int64, error) {
+func (r *repository) GetAll(filter Filter, limit, offset int) ([]Food, int64, error) {
 	var foods []Food
 	var total int64
 
-	if err := r.DB.Limit(limit).Offset(offset).Find(&foods).Error; err != nil {
+	query := r.DB.Model(&Food{})
+	order := clause.Expr{SQL: "name, id"}
+
+	if term := strings.ToLower(strings.TrimSpace(filter.Query)); term != "" {
+		query = query.Where(
+			"search_vector @@ websearch_to_tsquery('simple', immutable_unaccent(?)) OR immutable_unaccent(lower(name)) %> immutable_unaccent(?)",
+			term, term,
+		)
+		order = clause.Expr{
+			SQL:  "ts_rank(search_vector, websearch_to_tsquery('simple', immutable_unaccent(?))) + word_similarity(immutable_unaccent(?), immutable_unaccent(lower(name))) DESC, name, id",
+			Vars: []interface{}{term, term},
+		}
+	}
+
+	if filter.BrandID != "" {
+		query = query.Where("brand_id = ?", filter.BrandID)
+	}
+
+	if len(filter.ExcludeAllergens) > 0 {
+		query = query.Where("allergens_verified AND NOT (allergens && ?::text[])", db.StringArray(filter.ExcludeAllergens))
+	}
+
+	if len(filter.Diets) > 0 {
+		query = query.Where("diets @> ?::text[]", db.StringArray(filter.Diets))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order(order).Limit(limit).Offset(offset).Find(&foods).Error; err != nil {
 		return nil, 0, err
 	}
 
-	r.DB.Model(&Food{}).Count(&total)
 	return foods, total, nil
 }
 
@@ -40,14 +93,61 @@ func (r *repository) GetByID(id string) (*Food, error) {
 	return &food, nil
 }
 
+func (r *repository) GetByIDWithNutrients(id string) (*Food, error) {
+	food, err := r.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.DB.Joins("Nutrient").
+		Where("food_nutrients.food_id = ?", id).
+		Order(`"Nutrient".category, "Nutrient".name`).
+		Find(&food.Nutrients).Error
+	if err != nil {
+		return nil, err
+	}
+	return food, nil
+}
+
+func (r *repository) GetByBarcode(barcode string) (*Food, error) {
+	var food Food
+	if err := r.DB.First(&food, "barcode = ?", barcode).Error; err != nil {
+		return nil, err
+	}
+	return &food, nil
+}
+
+func (r *repository) BrandExists(brandID string) (bool, error) {
+	var count int64
+	if err := r.DB.Table("brands").Where("id = ?", brandID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *repository) Create(food *Food) error {
-	return r.DB.Create(food).Error
+	return r.DB.Omit(clause.Associations).Create(food).Error
 }
 
 func (r *repository) Update(food *Food) error {
-	return r.DB.Save(food).Error
+	return r.DB.Omit(clause.Associations).Save(food).Error
 }
 
 func (r *repository) Delete(id string) error {
 	return r.DB.Delete(&Food{}, "id = ?", id).Error
 }
+
+func (r *repository) GetPortions(foodID string) ([]Portion, error) {
+	var portions []Portion
+	err := r.DB.Where("food_id = ?", foodID).Order("grams").Find(&portions).Error
+	return portions, err
+}
+
+func (r *repository) CreatePortion(portion *Portion) error {
+	return r.DB.Create(portion).Error
+}
+
+func (r *repository) DeletePortion(foodID, portionID string) (int64, error) {
+	result := r.DB.Delete(&Portion{}, "id = ? AND food_id = ?", portionID, foodID)
+	return result.RowsAffected, result.Error
+}
@@ -9,9 +9,15 @@ func (h *Handler) Routes() chi.Router {
 
 	r.Get("/", h.GetAll)
 	r.Post("/", h.Create)
+	r.Get("/barcode/{code}", h.GetByBarcode)
 	r.Get("/{id}", h.GetByID)
 	r.Put("/{id}", h.Update)
 	r.Delete("/{id}", h.Delete)
 
+	r.Get("/{id}/portions", h.GetPortions)
+	r.Post("/{id}/portions", h.CreatePortion)
+	r.Delete("/{id}/portions/{portionId}", h.DeletePortion)
+	r.Get("/{id}/nutrition", h.GetNutrition)
+
 	return r
 }
@@ -1,23 +1,68 @@
 package food
 
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+var (
+	ErrPortionNotFound  = errors.New("portion not found")
+	ErrDuplicateBarcode = errors.New("barcode is already assigned to another food")
+	ErrBrandNotFound    = errors.New("brand not found")
+	ErrFoodInUse        = errors.New("food is still referenced")
+)
+
+// UpdateListener is notified after a food has been changed, e.g. to refresh
+// values derived from its nutrition.
+type UpdateListener interface {
+	FoodUpdated(id string) error
+}
+
 type Service struct {
-	Repo Repository
+	Repo      Repository
+	Listeners []UpdateListener
 }
 
 func NewService(repo Repository) *Service {
 	return &Service{Repo: repo}
 }
 
-func (s *Service) GetAll(limit, offset int) ([]Food, int64, error) {
-	return s.Repo.GetAll(limit, offset)
+func (s *Service) GetAll(filter Filter, limit, offset int) ([]Food, int64, error) {
+	return s.Repo.GetAll(filter, limit, offset)
 }
 
 func (s *Service) GetByID(id string) (*Food, error) {
 	return s.Repo.GetByID(id)
 }
 
+func (s *Service) GetByIDWithNutrients(id string) (*Food, error) {
+	return s.Repo.GetByIDWithNutrients(id)
+}
+
+func (s *Service) GetByBarcode(code string) (*Food, error) {
+	barcode, err := NormalizeBarcode(code)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetByBarcode(barcode)
+}
+
 func (s *Service) Create(food *Food) error {
-	return s.Repo.Create(food)
+	if err := s.prepareBarcode(food); err != nil {
+		return err
+	}
+	if err := s.ensureBrand(food.BrandID); err != nil {
+		return err
+	}
+	if err := normalizeFlags(food); err != nil {
+		return err
+	}
+
+	food.Nutrition.Normalize()
+	return translateDuplicate(s.Repo.Create(food))
 }
 
 func (s *Service) Update(food *Food) error {
@@ -26,11 +71,172 @@ func (s *Service) Update(food *Food) error {
 		return err
 	}
 
+	if err := s.prepareBarcode(food); err != nil {
+		return err
+	}
+	if err := s.ensureBrand(food.BrandID); err != nil {
+		return err
+	}
+	if err := normalizeFlags(food); err != nil {
+		return err
+	}
+
 	food.CreatedAt = existingFood.CreatedAt
+	food.Nutrition.Normalize()
+
+	if err := translateDuplicate(s.Repo.Update(food)); err != nil {
+		return err
+	}
 
-	return s.Repo.Update(food)
+	return s.notify(food.ID)
 }
 
 func (s *Service) Delete(id string) error {
-	return s.Repo.Delete(id)
+	if err := s.Repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrFoodInUse
+		}
+		return err
+	}
+	return nil
+}
+
+// Subscribe registers a listener for food updates.
+func (s *Service) Subscribe(listener UpdateListener) {
+	s.Listeners = append(s.Listeners, listener)
+}
+
+func (s *Service) notify(id string) error {
+	for _, listener := range s.Listeners {
+		if err := listener.FoodUpdated(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) GetPortions(foodID string) ([]Portion, error) {
+	if _, err := s.Repo.GetByID(foodID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetPortions(foodID)
+}
+
+func (s *Service) CreatePortion(portion *Portion) error {
+	if IsUnit(portion.Name) {
+		return ErrReservedUnit
+	}
+	if _, err := s.Repo.GetByID(portion.FoodID); err != nil {
+		return err
+	}
+	return s.Repo.CreatePortion(portion)
+}
+
+func (s *Service) DeletePortion(foodID, portionID string) error {
+	if _, err := s.Repo.GetByID(foodID); err != nil {
+		return err
+	}
+
+	removed, err := s.Repo.DeletePortion(foodID, portionID)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrPortionNotFound
+	}
+	return nil
+}
+
+// ToGrams converts an amount of a food, in a unit or one of the food's portions, into grams.
+func (s *Service) ToGrams(foodID string, amount float64, unit string) (float64, error) {
+	food, err := s.Repo.GetByID(foodID)
+	if err != nil {
+		return 0, err
+	}
+
+	portions, err := s.Repo.GetPortions(foodID)
+	if err != nil {
+		return 0, err
+	}
+
+	return ToGrams(food, portions, amount, unit)
+}
+
+// NutritionFor returns the nutrition of an amount of a food.
+func (s *Service) NutritionFor(foodID string, amount float64, unit string) (*Serving, error) {
+	food, err := s.Repo.GetByID(foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	portions, err := s.Repo.GetPortions(foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	grams, err := ToGrams(food, portions, amount, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Serving{
+		FoodID:    food.ID,
+		Amount:    amount,
+		Unit:      unit,
+		Grams:     reporting.Round(grams, 2),
+		Nutrition: food.Nutrition.Scale(grams / 100).Round(2),
+	}, nil
+}
+
+// prepareBarcode normalizes the barcode of a food to GTIN-14 and makes sure no other food uses it.
+func (s *Service) prepareBarcode(food *Food) error {
+	if food.Barcode == nil {
+		return nil
+	}
+	if *food.Barcode == "" {
+		food.Barcode = nil
+		return nil
+	}
+
+	barcode, err := NormalizeBarcode(*food.Barcode)
+	if err != nil {
+		return err
+	}
+	food.Barcode = &barcode
+
+	existing, err := s.Repo.GetByBarcode(barcode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != food.ID {
+		return ErrDuplicateBarcode
+	}
+	return nil
+}
+
+func (s *Service) ensureBrand(brandID *string) error {
+	if brandID == nil {
+		return nil
+	}
+
+	exists, err := s.Repo.BrandExists(*brandID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrBrandNotFound
+	}
+	return nil
+}
+
+// translateDuplicate maps a unique violation, raised when two requests race for
+// the same barcode, to ErrDuplicateBarcode.
+func translateDuplicate(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicateBarcode
+	}
+	return err
 }
@@ -0,0 +1,88 @@
+package food
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrUnknownUnit     = errors.New("unknown unit")
+	ErrDensityRequired = errors.New("food has no density, volume units cannot be converted")
+	ErrReservedUnit    = errors.New("portion name is a measurement unit")
+)
+
+type unitKind int
+
+const (
+	mass unitKind = iota
+	volume
+)
+
+type unit struct {
+	kind unitKind
+	// factor converts one unit into grams (mass) or millilitres (volume).
+	factor float64
+}
+
+// units lists the supported measurement units. Volumes without a prefix are US customary,
+// British imperial volumes are prefixed with "imp_".
+var units = map[string]unit{
+	"mg": {mass, 0.001},
+	"g":  {mass, 1},
+	"kg": {mass, 1000},
+	"oz": {mass, 28.349523125},
+	"lb": {mass, 453.59237},
+
+	"ml":         {volume, 1},
+	"cl":         {volume, 10},
+	"dl":         {volume, 100},
+	"l":          {volume, 1000},
+	"tsp":        {volume, 4.92892159375},
+	"tbsp":       {volume, 14.78676478125},
+	"fl_oz":      {volume, 29.5735295625},
+	"cup":        {volume, 236.5882365},
+	"metric_cup": {volume, 250},
+	"pint":       {volume, 473.176473},
+	"quart":      {volume, 946.352946},
+	"gallon":     {volume, 3785.411784},
+	"imp_fl_oz":  {volume, 28.4130625},
+	"imp_pint":   {volume, 568.26125},
+	"imp_quart":  {volume, 1136.5225},
+	"imp_gallon": {volume, 4546.09},
+}
+
+// ToGrams converts an amount of a food into grams. A unit is first matched against the mass
+// and volume units, then against the food's own portions (case-insensitively), so that a
+// portion can never change what a standard unit means. Volume units require the food to have
+// a density.
+func ToGrams(food *Food, portions []Portion, amount float64, unitName string) (float64, error) {
+	name := normalizeUnit(unitName)
+
+	if u, ok := units[name]; ok {
+		if u.kind == volume {
+			if food.Density == nil {
+				return 0, ErrDensityRequired
+			}
+			return amount * u.factor * *food.Density, nil
+		}
+		return amount * u.factor, nil
+	}
+
+	for _, portion := range portions {
+		if normalizeUnit(portion.Name) == name {
+			return amount * portion.Grams, nil
+		}
+	}
+
+	return 0, ErrUnknownUnit
+}
+
+// IsUnit reports whether name is a mass or volume unit, which portions may not be named after.
+func IsUnit(name string) bool {
+	_, ok := units[normalizeUnit(name)]
+	return ok
+}
+
+func normalizeUnit(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
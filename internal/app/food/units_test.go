@@ -0,0 +1,73 @@
+package food
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestToGrams(t *testing.T) {
+	density := 1.03
+	milk := &Food{Density: &density}
+	bread := &Food{}
+	portions := []Portion{
+		{Name: "Slice", Grams: 30},
+		{Name: "cup", Grams: 120},
+	}
+
+	tests := []struct {
+		name     string
+		food     *Food
+		portions []Portion
+		amount   float64
+		unit     string
+		want     float64
+		err      error
+	}{
+		{name: "grams", food: bread, amount: 150, unit: "g", want: 150},
+		{name: "milligrams", food: bread, amount: 500, unit: "mg", want: 0.5},
+		{name: "kilograms", food: bread, amount: 0.25, unit: "kg", want: 250},
+		{name: "ounces", food: bread, amount: 2, unit: "oz", want: 56.69904625},
+		{name: "pounds", food: bread, amount: 1, unit: "lb", want: 453.59237},
+		{name: "unit is trimmed and case-insensitive", food: bread, amount: 1, unit: " KG ", want: 1000},
+		{name: "millilitres use the density", food: milk, amount: 200, unit: "ml", want: 206},
+		{name: "litres", food: milk, amount: 1, unit: "l", want: 1030},
+		{name: "US cup", food: milk, amount: 1, unit: "cup", want: 243.68588359},
+		{name: "imperial pint", food: milk, amount: 1, unit: "imp_pint", want: 585.3090875},
+		{name: "volume without density", food: bread, amount: 1, unit: "ml", err: ErrDensityRequired},
+		{name: "portion", food: bread, portions: portions, amount: 2, unit: "slice", want: 60},
+		{name: "unit wins over a portion of the same name", food: milk, portions: portions, amount: 1, unit: "cup", want: 243.68588359},
+		{name: "unknown unit", food: bread, portions: portions, amount: 1, unit: "loaf", err: ErrUnknownUnit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToGrams(tt.food, tt.portions, tt.amount, tt.unit)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ToGrams(%v, %q) error = %v, want %v", tt.amount, tt.unit, err, tt.err)
+			}
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("ToGrams(%v, %q) = %v, want %v", tt.amount, tt.unit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "g", want: true},
+		{name: "TBSP", want: true},
+		{name: " imp_gallon ", want: true},
+		{name: "slice", want: false},
+		{name: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsUnit(tt.name); got != tt.want {
+			t.Errorf("IsUnit(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
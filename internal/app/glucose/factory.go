@@ -0,0 +1,17 @@
+package glucose
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger, diary diary.Service) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, diary)
+	validator := validator.New()
+	glucoseLogger := logger.Named("GlucoseHandler")
+
+	return NewHandler(service, validator, glucoseLogger)
+}
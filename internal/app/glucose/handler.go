@@ -0,0 +1,362 @@
+package glucose
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRankingDays is the length of a food ranking requested without "from".
+const defaultRankingDays = 30
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetReadings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	tag := r.URL.Query().Get("tag")
+	switch tag {
+	case "", TagFasting, TagPreMeal, TagPostMeal, TagRandom:
+	default:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'tag' parameter")
+		h.Logger.Warn("Invalid 'tag' parameter", zap.String("tag", tag))
+		return
+	}
+
+	readings, total, err := h.Service.GetReadings(userID, ReadingFilter{Range: period, Tag: tag}, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving glucose readings")
+		h.Logger.Error("Error retrieving glucose readings", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     readings,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(readings),
+	}
+
+	h.Logger.Info("Retrieved glucose readings", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(readings)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateReading(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var reading Reading
+	if err := json.NewDecoder(r.Body).Decode(&reading); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(reading); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateReading(userID, &reading); err != nil {
+		h.writeReadingError(w, err, "", "Error creating glucose reading")
+		return
+	}
+
+	h.Logger.Info("Created glucose reading", zap.String("id", reading.ID), zap.Float64("mg_dl", reading.MgDl))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(reading); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetReadingByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing glucose reading ID")
+		h.Logger.Warn("Missing glucose reading ID in request")
+		return
+	}
+
+	reading, err := h.Service.GetReadingByID(userID, id)
+	if err != nil {
+		h.writeReadingError(w, err, id, "Error retrieving glucose reading")
+		return
+	}
+
+	h.Logger.Info("Retrieved glucose reading", zap.String("id", reading.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reading); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateReading(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing glucose reading ID")
+		h.Logger.Warn("Missing glucose reading ID in request")
+		return
+	}
+
+	var updatedData Reading
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateReading(userID, &updatedData); err != nil {
+		h.writeReadingError(w, err, id, "Error updating glucose reading")
+		return
+	}
+
+	h.Logger.Info("Updated glucose reading", zap.String("id", updatedData.ID), zap.Float64("mg_dl", updatedData.MgDl))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteReading(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing glucose reading ID")
+		h.Logger.Warn("Missing glucose reading ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteReading(userID, id); err != nil {
+		h.writeReadingError(w, err, id, "Error deleting glucose reading")
+		return
+	}
+
+	h.Logger.Info("Deleted glucose reading", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetMealResponses(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	unit, ok := h.parseUnit(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	responses, total, err := h.Service.GetMealResponses(userID, period, unit, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error analysing meal responses")
+		h.Logger.Error("Error analysing meal responses", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     responses,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(responses),
+	}
+
+	h.Logger.Info("Analysed meal responses", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(responses)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetFoodResponses(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	unit, ok := h.parseUnit(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+	if period.To.IsZero() {
+		now := time.Now().In(location)
+		period.To = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, location)
+	}
+	if period.From.IsZero() {
+		period.From = period.To.AddDate(0, 0, -defaultRankingDays)
+	}
+
+	foods, err := h.Service.GetFoodResponses(userID, period, unit)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error ranking food responses")
+		h.Logger.Error("Error ranking food responses", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data": foods,
+		"from": period.From,
+		"to":   period.To,
+	}
+
+	h.Logger.Info("Ranked food responses", zap.Time("from", period.From), zap.Time("to", period.To), zap.Int("foods", len(foods)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+// parseUnit reads the optional "unit" query parameter, mg/dL by default.
+func (h *Handler) parseUnit(w http.ResponseWriter, r *http.Request) (string, bool) {
+	unit := r.URL.Query().Get("unit")
+	switch unit {
+	case "":
+		return UnitMgDl, true
+	case UnitMgDl, UnitMmolL:
+		return unit, true
+	default:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'unit' parameter, expected 'mg/dL' or 'mmol/L'")
+		h.Logger.Warn("Invalid 'unit' parameter", zap.String("unit", unit))
+		return "", false
+	}
+}
+
+func (h *Handler) writeReadingError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Glucose reading not found")
+		h.Logger.Warn("Glucose reading not found", zap.String("id", id))
+	case ErrValueOutOfRange:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Glucose value must be between 10 and 1000 mg/dL (0.56 and 55.5 mmol/L)")
+		h.Logger.Warn("Glucose value out of range", zap.String("id", id))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
@@ -0,0 +1,78 @@
+package glucose
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// Units a reading can be entered and reported in.
+const (
+	UnitMgDl  = "mg/dL"
+	UnitMmolL = "mmol/L"
+)
+
+// Tags describe when a reading was taken.
+const (
+	TagFasting  = "fasting"
+	TagPreMeal  = "pre_meal"
+	TagPostMeal = "post_meal"
+	TagRandom   = "random"
+)
+
+// Reading is a blood glucose measurement. Value and Unit are kept as entered;
+// MgDl and MmolL are computed when the reading is saved.
+type Reading struct {
+	ID         string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string    `json:"-" gorm:"type:uuid;not null"`
+	MeasuredAt time.Time `json:"measured_at" gorm:"not null" validate:"required"`
+	Value      float64   `json:"value" gorm:"not null" validate:"required,gt=0,lte=1000"`
+	Unit       string    `json:"unit" gorm:"not null" validate:"required,oneof=mg/dL mmol/L"`
+	MgDl       float64   `json:"mg_dl" gorm:"column:mg_dl;not null"`
+	MmolL      float64   `json:"mmol_l" gorm:"column:mmol_l;not null"`
+	Tag        string    `json:"tag" gorm:"not null;default:'random'" validate:"omitempty,oneof=fasting pre_meal post_meal random"`
+	Notes      string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Reading) TableName() string {
+	return "glucose_readings"
+}
+
+// ReadingFilter narrows reading queries. Empty fields match everything.
+type ReadingFilter struct {
+	reporting.Range
+	Tag string
+}
+
+// MealResponse is the glucose response to a diary meal. Baseline is the last
+// reading in the BaselineWindow before the meal; Peak is the highest reading
+// in the ResponseWindow after it. Rise and IAUC, the incremental area under the
+// curve above the baseline in unit × minutes, need a baseline.
+type MealResponse struct {
+	MealID        string    `json:"meal_id"`
+	MealType      string    `json:"meal_type"`
+	MealName      string    `json:"meal_name"`
+	ConsumedAt    time.Time `json:"consumed_at"`
+	Foods         []string  `json:"foods"`
+	Unit          string    `json:"unit"`
+	Readings      int       `json:"readings"`
+	Baseline      *float64  `json:"baseline"`
+	Peak          *float64  `json:"peak"`
+	TimeToPeakMin *float64  `json:"time_to_peak_min"`
+	Rise          *float64  `json:"rise"`
+	IAUC          *float64  `json:"iauc"`
+}
+
+// FoodResponse averages the responses to the meals a food was eaten in. Only
+// meals with a baseline and at least one reading after it count.
+type FoodResponse struct {
+	FoodID   string   `json:"food_id"`
+	Name     string   `json:"name"`
+	Unit     string   `json:"unit"`
+	Meals    int      `json:"meals"`
+	MeanRise *float64 `json:"mean_rise"`
+	MeanIAUC *float64 `json:"mean_iauc"`
+	MaxPeak  *float64 `json:"max_peak"`
+}
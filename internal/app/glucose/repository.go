@@ -0,0 +1,80 @@
+package glucose
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+type Repository interface {
+	GetReadings(userID string, filter ReadingFilter, limit, offset int) ([]Reading, int64, error)
+	GetReadingByID(userID, id string) (*Reading, error)
+	CreateReading(reading *Reading) error
+	UpdateReading(reading *Reading) error
+	DeleteReading(userID, id string) error
+
+	// GetReadingsInRange returns all of the user's readings taken in [From, To], ordered by time.
+	GetReadingsInRange(userID string, period reporting.Range) ([]Reading, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetReadings(userID string, filter ReadingFilter, limit, offset int) ([]Reading, int64, error) {
+	var readings []Reading
+	var total int64
+
+	query := r.db.Model(&Reading{}).Where("user_id = ?", userID)
+	if !filter.From.IsZero() {
+		query = query.Where("measured_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("measured_at < ?", filter.To)
+	}
+	if filter.Tag != "" {
+		query = query.Where("tag = ?", filter.Tag)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("measured_at DESC").Limit(limit).Offset(offset).Find(&readings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return readings, total, nil
+}
+
+func (r *repositoryImpl) GetReadingByID(userID, id string) (*Reading, error) {
+	var reading Reading
+	if err := r.db.First(&reading, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+func (r *repositoryImpl) CreateReading(reading *Reading) error {
+	return r.db.Create(reading).Error
+}
+
+func (r *repositoryImpl) UpdateReading(reading *Reading) error {
+	return r.db.Save(reading).Error
+}
+
+func (r *repositoryImpl) DeleteReading(userID, id string) error {
+	return r.db.Delete(&Reading{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetReadingsInRange(userID string, period reporting.Range) ([]Reading, error) {
+	var readings []Reading
+	err := r.db.Where("user_id = ? AND measured_at >= ? AND measured_at <= ?", userID, period.From, period.To).
+		Order("measured_at, id").
+		Find(&readings).Error
+	return readings, err
+}
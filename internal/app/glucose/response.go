@@ -0,0 +1,204 @@
+package glucose
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// MgDlPerMmolL converts glucose concentrations, from the molar mass of glucose.
+const MgDlPerMmolL = 18.0182
+
+// Valid glucose concentrations in mg/dL.
+const (
+	minMgDl = 10
+	maxMgDl = 1000
+)
+
+// BaselineWindow is how long before a meal a reading still serves as its baseline.
+const BaselineWindow = 30 * time.Minute
+
+// ResponseWindow is how long after a meal readings count towards its response.
+const ResponseWindow = 3 * time.Hour
+
+var ErrValueOutOfRange = errors.New("glucose value out of range")
+
+// normalize sets both canonical values of a reading from the value as entered.
+func normalize(reading *Reading) error {
+	mgDl := reading.Value
+	if reading.Unit == UnitMmolL {
+		mgDl = reading.Value * MgDlPerMmolL
+	}
+	if mgDl < minMgDl || mgDl > maxMgDl {
+		return ErrValueOutOfRange
+	}
+
+	if reading.Tag == "" {
+		reading.Tag = TagRandom
+	}
+	reading.MgDl = reporting.Round(mgDl, 1)
+	reading.MmolL = reporting.Round(mgDl/MgDlPerMmolL, 2)
+	return nil
+}
+
+// analyze computes the response to a meal from readings sorted by time.
+func analyze(meal diary.Meal, readings []Reading, unit string) MealResponse {
+	response := MealResponse{
+		MealID:     meal.ID,
+		MealType:   meal.Type,
+		MealName:   meal.Name,
+		ConsumedAt: meal.ConsumedAt,
+		Foods:      []string{},
+		Unit:       unit,
+	}
+	seen := map[string]bool{}
+	for _, entry := range meal.Entries {
+		if entry.Food != nil && !seen[entry.FoodID] {
+			seen[entry.FoodID] = true
+			response.Foods = append(response.Foods, entry.Food.Name)
+		}
+	}
+
+	start := meal.ConsumedAt
+	end := start.Add(ResponseWindow)
+
+	var baseline *Reading
+	var curve []point
+	for i, reading := range readings {
+		switch {
+		case reading.MeasuredAt.Before(start.Add(-BaselineWindow)) || reading.MeasuredAt.After(end):
+			continue
+		case !reading.MeasuredAt.After(start):
+			baseline = &readings[i]
+		default:
+			curve = append(curve, point{minutes: reading.MeasuredAt.Sub(start).Minutes(), mgDl: reading.MgDl})
+		}
+	}
+	response.Readings = len(curve)
+	if len(curve) == 0 {
+		return response
+	}
+
+	peak := curve[0]
+	for _, p := range curve[1:] {
+		if p.mgDl > peak.mgDl {
+			peak = p
+		}
+	}
+	response.Peak = convert(peak.mgDl, unit)
+	timeToPeak := reporting.Round(peak.minutes, 1)
+	response.TimeToPeakMin = &timeToPeak
+
+	if baseline != nil {
+		response.Baseline = convert(baseline.MgDl, unit)
+		response.Rise = convert(peak.mgDl-baseline.MgDl, unit)
+		response.IAUC = convert(iauc(baseline.MgDl, curve), unit)
+	}
+	return response
+}
+
+type point struct {
+	minutes float64
+	mgDl    float64
+}
+
+// iauc integrates the curve above the baseline with the trapezoidal rule,
+// starting from the baseline at the meal and ignoring the area below it.
+func iauc(baseline float64, curve []point) float64 {
+	area := 0.0
+	previous := point{minutes: 0, mgDl: baseline}
+	for _, p := range curve {
+		width := p.minutes - previous.minutes
+		d1, d2 := previous.mgDl-baseline, p.mgDl-baseline
+		switch {
+		case d1 >= 0 && d2 >= 0:
+			area += (d1 + d2) / 2 * width
+		case d1 > 0:
+			area += d1 * d1 / (d1 - d2) * width / 2
+		case d2 > 0:
+			area += d2 * d2 / (d2 - d1) * width / 2
+		}
+		previous = p
+	}
+	return area
+}
+
+// rankFoods averages the meal responses per food, highest mean iAUC first.
+func rankFoods(meals []diary.Meal, responses []MealResponse, unit string) []FoodResponse {
+	type sums struct {
+		name       string
+		meals      int
+		rise, area float64
+		maxPeak    float64
+	}
+	byFood := map[string]*sums{}
+
+	for i, meal := range meals {
+		response := responses[i]
+		if response.IAUC == nil {
+			continue
+		}
+
+		seen := map[string]bool{}
+		for _, entry := range meal.Entries {
+			if entry.Food == nil || seen[entry.FoodID] {
+				continue
+			}
+			seen[entry.FoodID] = true
+
+			food, ok := byFood[entry.FoodID]
+			if !ok {
+				food = &sums{name: entry.Food.Name}
+				byFood[entry.FoodID] = food
+			}
+			food.meals++
+			food.rise += *response.Rise
+			food.area += *response.IAUC
+			food.maxPeak = math.Max(food.maxPeak, *response.Peak)
+		}
+	}
+
+	foods := []FoodResponse{}
+	for id, food := range byFood {
+		meanRise := reporting.Round(food.rise/float64(food.meals), places(unit))
+		meanIAUC := reporting.Round(food.area/float64(food.meals), places(unit))
+		maxPeak := food.maxPeak
+		foods = append(foods, FoodResponse{
+			FoodID:   id,
+			Name:     food.name,
+			Unit:     unit,
+			Meals:    food.meals,
+			MeanRise: &meanRise,
+			MeanIAUC: &meanIAUC,
+			MaxPeak:  &maxPeak,
+		})
+	}
+	sort.Slice(foods, func(i, j int) bool {
+		if *foods[i].MeanIAUC != *foods[j].MeanIAUC {
+			return *foods[i].MeanIAUC > *foods[j].MeanIAUC
+		}
+		return foods[i].Name < foods[j].Name
+	})
+	return foods
+}
+
+// convert returns a concentration in mg/dL in the unit, rounded for display.
+func convert(mgDl float64, unit string) *float64 {
+	value := mgDl
+	if unit == UnitMmolL {
+		value = mgDl / MgDlPerMmolL
+	}
+	value = reporting.Round(value, places(unit))
+	return &value
+}
+
+func places(unit string) int {
+	if unit == UnitMmolL {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,128 @@
+package glucose
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+)
+
+func TestIAUC(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseline float64
+		curve    []point
+		want     float64
+	}{
+		{name: "no readings", baseline: 100, want: 0},
+		{
+			name:     "rise and return",
+			baseline: 100,
+			curve:    []point{{30, 140}, {60, 120}, {90, 100}},
+			want:     1800,
+		},
+		{
+			name:     "dip below the baseline is ignored",
+			baseline: 100,
+			curve:    []point{{30, 80}, {60, 120}},
+			want:     150,
+		},
+		{
+			name:     "fall through the baseline",
+			baseline: 100,
+			curve:    []point{{30, 140}, {60, 60}},
+			want:     900,
+		},
+		{
+			name:     "entirely below the baseline",
+			baseline: 100,
+			curve:    []point{{30, 90}, {60, 80}},
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := iauc(tt.baseline, tt.curve); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("iauc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	meal := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	readings := []Reading{
+		{MeasuredAt: meal.Add(-40 * time.Minute), MgDl: 90},
+		{MeasuredAt: meal.Add(-20 * time.Minute), MgDl: 100},
+		{MeasuredAt: meal.Add(30 * time.Minute), MgDl: 140},
+		{MeasuredAt: meal.Add(60 * time.Minute), MgDl: 120},
+		{MeasuredAt: meal.Add(90 * time.Minute), MgDl: 100},
+		{MeasuredAt: meal.Add(4 * time.Hour), MgDl: 200},
+	}
+
+	response := analyze(diary.Meal{ConsumedAt: meal}, readings, UnitMgDl)
+	if response.Readings != 3 {
+		t.Errorf("Readings = %d, want 3", response.Readings)
+	}
+	checks := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"Baseline", response.Baseline, 100},
+		{"Peak", response.Peak, 140},
+		{"Rise", response.Rise, 40},
+		{"IAUC", response.IAUC, 1800},
+		{"TimeToPeakMin", response.TimeToPeakMin, 30},
+	}
+	for _, c := range checks {
+		if c.got == nil || *c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	response = analyze(diary.Meal{ConsumedAt: meal}, readings[2:5], UnitMgDl)
+	if response.Baseline != nil || response.IAUC != nil {
+		t.Errorf("without a baseline got Baseline %v and IAUC %v, want nil", response.Baseline, response.IAUC)
+	}
+	if response.Peak == nil || *response.Peak != 140 {
+		t.Errorf("without a baseline Peak = %v, want 140", response.Peak)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		unit  string
+		mgDl  float64
+		mmolL float64
+		err   error
+	}{
+		{name: "mg/dL", value: 100, unit: UnitMgDl, mgDl: 100, mmolL: 5.55},
+		{name: "mmol/L", value: 5.5, unit: UnitMmolL, mgDl: 99.1, mmolL: 5.5},
+		{name: "too low", value: 0.4, unit: UnitMmolL, err: ErrValueOutOfRange},
+		{name: "too high", value: 1001, unit: UnitMgDl, err: ErrValueOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := &Reading{Value: tt.value, Unit: tt.unit}
+			if err := normalize(reading); !errors.Is(err, tt.err) {
+				t.Fatalf("normalize() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if reading.MgDl != tt.mgDl || reading.MmolL != tt.mmolL {
+				t.Errorf("normalize() = %v mg/dL, %v mmol/L, want %v, %v", reading.MgDl, reading.MmolL, tt.mgDl, tt.mmolL)
+			}
+			if reading.Tag != TagRandom {
+				t.Errorf("Tag = %q, want %q", reading.Tag, TagRandom)
+			}
+		})
+	}
+}
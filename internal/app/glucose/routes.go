@@ -0,0 +1,18 @@
+package glucose
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/readings", h.GetReadings)
+	r.Post("/readings", h.CreateReading)
+	r.Get("/readings/{id}", h.GetReadingByID)
+	r.Put("/readings/{id}", h.UpdateReading)
+	r.Delete("/readings/{id}", h.DeleteReading)
+
+	r.Get("/meals", h.GetMealResponses)
+	r.Get("/foods", h.GetFoodResponses)
+
+	return r
+}
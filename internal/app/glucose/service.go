@@ -0,0 +1,119 @@
+package glucose
+
+import (
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// maxRankedMeals bounds the meals a food ranking is computed from.
+const maxRankedMeals = 1000
+
+type Service interface {
+	GetReadings(userID string, filter ReadingFilter, limit, offset int) ([]Reading, int64, error)
+	GetReadingByID(userID, id string) (*Reading, error)
+	CreateReading(userID string, reading *Reading) error
+	UpdateReading(userID string, reading *Reading) error
+	DeleteReading(userID, id string) error
+
+	// GetMealResponses analyses the glucose response to the meals the user ate in
+	// the range, in the order they were eaten.
+	GetMealResponses(userID string, period reporting.Range, unit string, limit, offset int) ([]MealResponse, int64, error)
+	// GetFoodResponses ranks the foods the user ate in the range by their mean response.
+	GetFoodResponses(userID string, period reporting.Range, unit string) ([]FoodResponse, error)
+}
+
+type serviceImpl struct {
+	repo  Repository
+	diary diary.Service
+}
+
+func NewService(repo Repository, diary diary.Service) Service {
+	return &serviceImpl{repo: repo, diary: diary}
+}
+
+func (s *serviceImpl) GetReadings(userID string, filter ReadingFilter, limit, offset int) ([]Reading, int64, error) {
+	return s.repo.GetReadings(userID, filter, limit, offset)
+}
+
+func (s *serviceImpl) GetReadingByID(userID, id string) (*Reading, error) {
+	return s.repo.GetReadingByID(userID, id)
+}
+
+func (s *serviceImpl) CreateReading(userID string, reading *Reading) error {
+	reading.UserID = userID
+	if err := normalize(reading); err != nil {
+		return err
+	}
+
+	return s.repo.CreateReading(reading)
+}
+
+func (s *serviceImpl) UpdateReading(userID string, reading *Reading) error {
+	existingReading, err := s.repo.GetReadingByID(userID, reading.ID)
+	if err != nil {
+		return err
+	}
+
+	reading.UserID = userID
+	if err := normalize(reading); err != nil {
+		return err
+	}
+
+	reading.CreatedAt = existingReading.CreatedAt
+	return s.repo.UpdateReading(reading)
+}
+
+func (s *serviceImpl) DeleteReading(userID, id string) error {
+	if _, err := s.repo.GetReadingByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteReading(userID, id)
+}
+
+func (s *serviceImpl) GetMealResponses(userID string, period reporting.Range, unit string, limit, offset int) ([]MealResponse, int64, error) {
+	meals, total, err := s.diary.GetMeals(userID, period, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses, err := s.analyze(userID, meals, unit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return responses, total, nil
+}
+
+func (s *serviceImpl) GetFoodResponses(userID string, period reporting.Range, unit string) ([]FoodResponse, error) {
+	meals, _, err := s.diary.GetMeals(userID, period, maxRankedMeals, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.analyze(userID, meals, unit)
+	if err != nil {
+		return nil, err
+	}
+	return rankFoods(meals, responses, unit), nil
+}
+
+// analyze loads the user's readings around the meals, which are ordered by time,
+// and computes the response to each.
+func (s *serviceImpl) analyze(userID string, meals []diary.Meal, unit string) ([]MealResponse, error) {
+	responses := []MealResponse{}
+	if len(meals) == 0 {
+		return responses, nil
+	}
+
+	readings, err := s.repo.GetReadingsInRange(userID, reporting.Range{
+		From: meals[0].ConsumedAt.Add(-BaselineWindow),
+		To:   meals[len(meals)-1].ConsumedAt.Add(ResponseWindow),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, meal := range meals {
+		responses = append(responses, analyze(meal, readings, unit))
+	}
+	return responses, nil
+}
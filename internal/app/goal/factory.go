@@ -0,0 +1,19 @@
+package goal
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(database *gorm.DB, logger *zap.Logger, diary diary.Service) *Handler {
+	repo := NewRepository(database)
+	service := NewService(repo, diary)
+	validator := validator.New()
+	validator.RegisterCustomTypeFunc(db.ValidateValuer, db.Date{})
+	goalLogger := logger.Named("GoalHandler")
+
+	return NewHandler(service, validator, goalLogger)
+}
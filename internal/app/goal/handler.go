@@ -0,0 +1,295 @@
+package goal
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	goals, total, err := h.Service.GetAll(userID, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving goals")
+		h.Logger.Error("Error retrieving goals", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     goals,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(goals),
+	}
+
+	h.Logger.Info("Retrieved goals", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(goals)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var goal Goal
+	if err := json.NewDecoder(r.Body).Decode(&goal); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(goal); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(userID, &goal); err != nil {
+		h.writeError(w, err, "", "Error creating goal")
+		return
+	}
+
+	h.Logger.Info("Created new goal", zap.String("id", goal.ID), zap.Stringer("effective_from", goal.EffectiveFrom))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(goal); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing goal ID")
+		h.Logger.Warn("Missing goal ID in request")
+		return
+	}
+
+	goal, err := h.Service.GetByID(userID, id)
+	if err != nil {
+		h.writeError(w, err, id, "Error retrieving goal")
+		return
+	}
+
+	h.Logger.Info("Retrieved goal", zap.String("id", goal.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(goal); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing goal ID")
+		h.Logger.Warn("Missing goal ID in request")
+		return
+	}
+
+	var updatedData Goal
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(userID, &updatedData); err != nil {
+		h.writeError(w, err, id, "Error updating goal")
+		return
+	}
+
+	h.Logger.Info("Updated goal", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing goal ID")
+		h.Logger.Warn("Missing goal ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(userID, id); err != nil {
+		h.writeError(w, err, id, "Error deleting goal")
+		return
+	}
+
+	h.Logger.Info("Deleted goal", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCurrent returns the goal in effect on the optional "date", today by default.
+func (h *Handler) GetCurrent(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	day, ok := h.parseDay(w, r)
+	if !ok {
+		return
+	}
+
+	goal, err := h.Service.GetEffective(userID, db.NewDate(day))
+	if err != nil {
+		h.writeError(w, err, "", "Error retrieving current goal")
+		return
+	}
+
+	h.Logger.Info("Retrieved current goal", zap.String("id", goal.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(goal); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	day, ok := h.parseDay(w, r)
+	if !ok {
+		return
+	}
+
+	progress, err := h.Service.GetProgress(userID, day)
+	if err != nil {
+		h.writeError(w, err, "", "Error calculating goal progress")
+		return
+	}
+
+	h.Logger.Info("Calculated goal progress", zap.String("date", progress.Date), zap.String("goal_id", progress.Goal.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(progress); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+// parseDay returns local midnight of the "date" query parameter in the optional
+// "tz" time zone (the profile time zone by default). Without a date, today is used.
+func (h *Handler) parseDay(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	date := db.NewDate(time.Now().In(location))
+	if value := r.URL.Query().Get("date"); value != "" {
+		parsed, err := db.ParseDate(value)
+		if err != nil {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'date' parameter, expected YYYY-MM-DD")
+			h.Logger.Warn("Invalid 'date' parameter", zap.String("date", value))
+			return time.Time{}, false
+		}
+		date = parsed
+	}
+
+	return date.In(location), true
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Goal not found")
+		h.Logger.Warn("Goal not found", zap.String("id", id))
+	case ErrNoGoal:
+		errors.WriteHTTPError(w, http.StatusNotFound, "No goal in effect on this date")
+		h.Logger.Warn("No goal in effect")
+	case ErrGoalExists:
+		errors.WriteHTTPError(w, http.StatusConflict, "A goal already starts on this date")
+		h.Logger.Warn("Duplicate goal start date", zap.String("id", id))
+	case ErrUnknownNutrient:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Unknown target nutrient")
+		h.Logger.Warn("Unknown target nutrient", zap.String("id", id))
+	case ErrPercentNotAllowed:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Only energy-yielding macronutrients can be targeted as a share of energy")
+		h.Logger.Warn("Percentage target not allowed", zap.String("id", id))
+	case ErrEnergyTargetRequired:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Percentage targets need an energy_kcal target amount")
+		h.Logger.Warn("Percentage target without energy target", zap.String("id", id))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
@@ -0,0 +1,28 @@
+package goal
+
+import "github.com/v-vovk/health-tracker-api/internal/app/diary"
+
+// macro describes a nutrition field that can be targeted directly.
+type macro struct {
+	name string
+	unit string
+	// kcalPerGram converts a share of energy into grams; zero means the field
+	// cannot be targeted as a percentage of energy.
+	kcalPerGram float64
+	intake      func(totals diary.Totals) float64
+}
+
+// energyKey is the target that percentage-of-energy targets are relative to.
+const energyKey = "energy_kcal"
+
+var macros = map[string]macro{
+	"energy_kcal":     {"Energy", "kcal", 0, func(t diary.Totals) float64 { return t.EnergyKcal }},
+	"energy_kj":       {"Energy", "kJ", 0, func(t diary.Totals) float64 { return t.EnergyKJ }},
+	"protein_g":       {"Protein", "g", 4, func(t diary.Totals) float64 { return t.ProteinG }},
+	"fat_g":           {"Fat", "g", 9, func(t diary.Totals) float64 { return t.FatG }},
+	"saturated_fat_g": {"Saturated fat", "g", 9, func(t diary.Totals) float64 { return t.SaturatedFatG }},
+	"carbohydrate_g":  {"Carbohydrate", "g", 4, func(t diary.Totals) float64 { return t.CarbohydrateG }},
+	"sugar_g":         {"Sugar", "g", 4, func(t diary.Totals) float64 { return t.SugarG }},
+	"fiber_g":         {"Fiber", "g", 0, func(t diary.Totals) float64 { return t.FiberG }},
+	"sodium_mg":       {"Sodium", "mg", 0, func(t diary.Totals) float64 { return t.SodiumMg }},
+}
@@ -0,0 +1,79 @@
+package goal
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/hydration"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+)
+
+// Target types decide how intake is compared with the amount.
+const (
+	// TypeTarget is met within TargetTolerance of the amount.
+	TypeTarget = "target"
+	// TypeMin is met once intake reaches the amount.
+	TypeMin = "min"
+	// TypeMax is met while intake stays at or below the amount.
+	TypeMax = "max"
+)
+
+// TargetTolerance is the relative deviation still counted as meeting a TypeTarget target.
+const TargetTolerance = 0.1
+
+// Progress statuses.
+const (
+	StatusUnder = "under"
+	StatusMet   = "met"
+	StatusOver  = "over"
+)
+
+// Goal is a set of daily targets that applies from EffectiveFrom until the next goal starts.
+type Goal struct {
+	ID            string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string    `json:"-" gorm:"type:uuid;not null"`
+	EffectiveFrom db.Date   `json:"effective_from" gorm:"not null;uniqueIndex" validate:"required"`
+	Notes         string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	Targets       []Target  `json:"targets" gorm:"foreignKey:GoalID" validate:"required,min=1,max=100,unique=Nutrient,dive"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Target is a daily amount of energy, a macronutrient or a catalogue nutrient.
+// Nutrient is a nutrition field such as "energy_kcal" or "protein_g", or the code
+// of a catalogue nutrient. Macronutrients may be given as PercentEnergy instead
+// of Amount, relative to the goal's energy_kcal target.
+type Target struct {
+	ID            string   `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	GoalID        string   `json:"goal_id" gorm:"type:uuid;not null"`
+	Nutrient      string   `json:"nutrient" gorm:"not null" validate:"required,max=50"`
+	Type          string   `json:"type" gorm:"not null;default:'target'" validate:"omitempty,oneof=target min max"`
+	Amount        *float64 `json:"amount,omitempty" validate:"required_without=PercentEnergy,excluded_with=PercentEnergy,omitempty,gt=0,lte=1000000"`
+	PercentEnergy *float64 `json:"percent_energy,omitempty" validate:"omitempty,gt=0,lte=100"`
+}
+
+func (Target) TableName() string {
+	return "goal_targets"
+}
+
+// NutrientProgress compares a target with the intake of a day.
+type NutrientProgress struct {
+	Nutrient      string   `json:"nutrient"`
+	Name          string   `json:"name"`
+	Unit          string   `json:"unit"`
+	Type          string   `json:"type"`
+	Target        float64  `json:"target"`
+	PercentEnergy *float64 `json:"percent_energy,omitempty"`
+	Consumed      float64  `json:"consumed"`
+	Remaining     float64  `json:"remaining"`
+	Percent       float64  `json:"percent"`
+	Status        string   `json:"status"`
+}
+
+// Progress is the intake of a day measured against the goal in effect on that day.
+type Progress struct {
+	Date      string             `json:"date"`
+	TimeZone  string             `json:"time_zone"`
+	Goal      *Goal              `json:"goal"`
+	Nutrients []NutrientProgress `json:"nutrients"`
+	Hydration *hydration.Day     `json:"hydration"`
+}
@@ -0,0 +1,115 @@
+package goal
+
+import (
+	"github.com/v-vovk/health-tracker-api/internal/app/nutrient"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetAll(userID string, limit, offset int) ([]Goal, int64, error)
+	GetByID(userID, id string) (*Goal, error)
+	GetEffective(userID string, date db.Date) (*Goal, error)
+	Create(goal *Goal) error
+	Update(goal *Goal) error
+	Delete(userID, id string) error
+	GetNutrients(codes []string) ([]nutrient.Nutrient, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(userID string, limit, offset int) ([]Goal, int64, error) {
+	var goals []Goal
+	var total int64
+
+	if err := r.db.Model(&Goal{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Preload("Targets", orderByNutrient).
+		Where("user_id = ?", userID).
+		Order("effective_from DESC").
+		Limit(limit).Offset(offset).
+		Find(&goals).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return goals, total, nil
+}
+
+func (r *repositoryImpl) GetByID(userID, id string) (*Goal, error) {
+	var goal Goal
+	if err := r.db.Preload("Targets", orderByNutrient).First(&goal, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// GetEffective returns the user's goal with the latest start on or before the date.
+func (r *repositoryImpl) GetEffective(userID string, date db.Date) (*Goal, error) {
+	var goal Goal
+	err := r.db.Preload("Targets", orderByNutrient).
+		Where("user_id = ? AND effective_from <= ?", userID, date).
+		Order("effective_from DESC").
+		First(&goal).Error
+	if err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// Create stores the goal together with its targets.
+func (r *repositoryImpl) Create(goal *Goal) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(goal).Error; err != nil {
+			return err
+		}
+		return createTargets(tx, goal)
+	})
+}
+
+// Update saves the goal and replaces all of its targets.
+func (r *repositoryImpl) Update(goal *Goal) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(goal).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&Target{}, "goal_id = ?", goal.ID).Error; err != nil {
+			return err
+		}
+		return createTargets(tx, goal)
+	})
+}
+
+func (r *repositoryImpl) Delete(userID, id string) error {
+	return r.db.Delete(&Goal{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetNutrients(codes []string) ([]nutrient.Nutrient, error) {
+	var nutrients []nutrient.Nutrient
+	if len(codes) == 0 {
+		return nutrients, nil
+	}
+	err := r.db.Where("code IN ?", codes).Find(&nutrients).Error
+	return nutrients, err
+}
+
+func createTargets(tx *gorm.DB, goal *Goal) error {
+	for i := range goal.Targets {
+		goal.Targets[i].ID = ""
+		goal.Targets[i].GoalID = goal.ID
+	}
+	return tx.Create(&goal.Targets).Error
+}
+
+func orderByNutrient(db *gorm.DB) *gorm.DB {
+	return db.Order("nutrient")
+}
@@ -0,0 +1,33 @@
+package goal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db/dbtest"
+)
+
+func TestGetEffectiveQuery(t *testing.T) {
+	database, recorder := dbtest.Open(t)
+	repo := NewRepository(database)
+
+	// A dry run finds no rows, so only the statement is checked.
+	_, _ = repo.GetEffective("user-1", db.NewDate(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)))
+	if len(recorder.Statements) == 0 {
+		t.Fatal("no statement sent")
+	}
+
+	// The latest goal starting on or before the date is in effect.
+	statement := recorder.Statements[0]
+	for _, want := range []string{
+		"user_id = 'user-1' AND effective_from <= '2026-03-02'",
+		"ORDER BY effective_from DESC",
+		"LIMIT 1",
+	} {
+		if !strings.Contains(statement, want) {
+			t.Errorf("GetEffective() sent %s, want it to contain %q", statement, want)
+		}
+	}
+}
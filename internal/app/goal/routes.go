@@ -0,0 +1,17 @@
+package goal
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetAll)
+	r.Post("/", h.Create)
+	r.Get("/current", h.GetCurrent)
+	r.Get("/progress", h.GetProgress)
+	r.Get("/{id}", h.GetByID)
+	r.Put("/{id}", h.Update)
+	r.Delete("/{id}", h.Delete)
+
+	return r
+}
@@ -0,0 +1,281 @@
+package goal
+
+import (
+	"errors"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/app/nutrient"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNoGoal               = errors.New("no goal in effect")
+	ErrGoalExists           = errors.New("a goal already starts on this date")
+	ErrUnknownNutrient      = errors.New("unknown nutrient")
+	ErrPercentNotAllowed    = errors.New("nutrient cannot be targeted as a share of energy")
+	ErrEnergyTargetRequired = errors.New("percentage targets need an energy_kcal amount")
+)
+
+type Service interface {
+	GetAll(userID string, limit, offset int) ([]Goal, int64, error)
+	GetByID(userID, id string) (*Goal, error)
+	GetEffective(userID string, date db.Date) (*Goal, error)
+	Create(userID string, goal *Goal) error
+	Update(userID string, goal *Goal) error
+	Delete(userID, id string) error
+
+	// GetProgress compares the user's goal in effect on the day starting at the
+	// given local midnight with the intake logged in the diary.
+	GetProgress(userID string, day time.Time) (*Progress, error)
+
+	// CheckNutrient returns ErrUnknownNutrient unless a target can be set for
+	// the nutrient.
+	CheckNutrient(code string) error
+}
+
+type serviceImpl struct {
+	repo  Repository
+	diary diary.Service
+}
+
+func NewService(repo Repository, diary diary.Service) Service {
+	return &serviceImpl{repo: repo, diary: diary}
+}
+
+func (s *serviceImpl) GetAll(userID string, limit, offset int) ([]Goal, int64, error) {
+	return s.repo.GetAll(userID, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(userID, id string) (*Goal, error) {
+	return s.repo.GetByID(userID, id)
+}
+
+func (s *serviceImpl) GetEffective(userID string, date db.Date) (*Goal, error) {
+	goal, err := s.repo.GetEffective(userID, date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoGoal
+		}
+		return nil, err
+	}
+	return goal, nil
+}
+
+func (s *serviceImpl) Create(userID string, goal *Goal) error {
+	goal.UserID = userID
+	if err := s.checkTargets(goal); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(goal); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrGoalExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) Update(userID string, goal *Goal) error {
+	existingGoal, err := s.repo.GetByID(userID, goal.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkTargets(goal); err != nil {
+		return err
+	}
+
+	goal.UserID = userID
+	goal.CreatedAt = existingGoal.CreatedAt
+	if err := s.repo.Update(goal); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrGoalExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) Delete(userID, id string) error {
+	if _, err := s.repo.GetByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(userID, id)
+}
+
+func (s *serviceImpl) GetProgress(userID string, day time.Time) (*Progress, error) {
+	goal, err := s.GetEffective(userID, db.NewDate(day))
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.diary.GetDaySummary(userID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue, err := s.catalogueNutrients(goal)
+	if err != nil {
+		return nil, err
+	}
+
+	consumedNutrients := map[string]float64{}
+	for _, total := range summary.Totals.Nutrients {
+		consumedNutrients[total.Code] = total.Amount
+	}
+
+	progress := &Progress{
+		Date:      summary.Date,
+		TimeZone:  summary.TimeZone,
+		Goal:      goal,
+		Nutrients: make([]NutrientProgress, 0, len(goal.Targets)),
+		Hydration: summary.Hydration,
+	}
+
+	energy := energyAmount(goal)
+	for _, target := range goal.Targets {
+		item := NutrientProgress{
+			Nutrient:      target.Nutrient,
+			Type:          target.Type,
+			PercentEnergy: target.PercentEnergy,
+		}
+
+		if m, ok := macros[target.Nutrient]; ok {
+			item.Name = m.name
+			item.Unit = m.unit
+			item.Consumed = m.intake(summary.Totals)
+			if target.PercentEnergy != nil {
+				item.Target = energy * *target.PercentEnergy / 100 / m.kcalPerGram
+			} else {
+				item.Target = *target.Amount
+			}
+		} else {
+			n := catalogue[target.Nutrient]
+			item.Name = n.Name
+			item.Unit = n.Unit
+			item.Consumed = consumedNutrients[target.Nutrient]
+			item.Target = *target.Amount
+		}
+
+		item.Target = reporting.Round(item.Target, 2)
+		item.Remaining = reporting.Round(item.Target-item.Consumed, 2)
+		item.Percent = reporting.Round(item.Consumed/item.Target*100, 1)
+		item.Status = status(target.Type, item.Consumed, item.Target)
+		progress.Nutrients = append(progress.Nutrients, item)
+	}
+
+	return progress, nil
+}
+
+func (s *serviceImpl) CheckNutrient(code string) error {
+	if _, ok := macros[code]; ok {
+		return nil
+	}
+	nutrients, err := s.repo.GetNutrients([]string{code})
+	if err != nil {
+		return err
+	}
+	if len(nutrients) == 0 {
+		return ErrUnknownNutrient
+	}
+	return nil
+}
+
+// checkTargets validates the target nutrients and defaults their type.
+func (s *serviceImpl) checkTargets(goal *Goal) error {
+	var codes []string
+	hasPercent := false
+	for i := range goal.Targets {
+		target := &goal.Targets[i]
+		if target.Type == "" {
+			target.Type = TypeTarget
+		}
+
+		m, ok := macros[target.Nutrient]
+		if !ok {
+			if target.PercentEnergy != nil {
+				return ErrPercentNotAllowed
+			}
+			codes = append(codes, target.Nutrient)
+			continue
+		}
+
+		if target.PercentEnergy != nil {
+			if m.kcalPerGram == 0 {
+				return ErrPercentNotAllowed
+			}
+			hasPercent = true
+		}
+	}
+
+	if hasPercent && energyAmount(goal) == 0 {
+		return ErrEnergyTargetRequired
+	}
+
+	nutrients, err := s.repo.GetNutrients(codes)
+	if err != nil {
+		return err
+	}
+	if len(nutrients) != len(codes) {
+		return ErrUnknownNutrient
+	}
+	return nil
+}
+
+func (s *serviceImpl) catalogueNutrients(goal *Goal) (map[string]nutrient.Nutrient, error) {
+	var codes []string
+	for _, target := range goal.Targets {
+		if _, ok := macros[target.Nutrient]; !ok {
+			codes = append(codes, target.Nutrient)
+		}
+	}
+
+	nutrients, err := s.repo.GetNutrients(codes)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]nutrient.Nutrient, len(nutrients))
+	for _, n := range nutrients {
+		byCode[n.Code] = n
+	}
+	return byCode, nil
+}
+
+// energyAmount returns the goal's energy_kcal amount, or zero if it has none.
+func energyAmount(goal *Goal) float64 {
+	for _, target := range goal.Targets {
+		if target.Nutrient == energyKey && target.Amount != nil {
+			return *target.Amount
+		}
+	}
+	return 0
+}
+
+func status(targetType string, consumed, target float64) string {
+	switch targetType {
+	case TypeMin:
+		if consumed >= target {
+			return StatusMet
+		}
+		return StatusUnder
+	case TypeMax:
+		if consumed <= target {
+			return StatusMet
+		}
+		return StatusOver
+	default:
+		switch {
+		case consumed < target*(1-TargetTolerance):
+			return StatusUnder
+		case consumed > target*(1+TargetTolerance):
+			return StatusOver
+		default:
+			return StatusMet
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package goal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/diary"
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/app/nutrient"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"gorm.io/gorm"
+)
+
+// fakeRepository returns goal as the one in effect and records the dates asked for.
+type fakeRepository struct {
+	Repository
+	goal  *Goal
+	dates []string
+}
+
+func (r *fakeRepository) GetEffective(userID string, date db.Date) (*Goal, error) {
+	r.dates = append(r.dates, date.String())
+	if r.goal == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.goal, nil
+}
+
+func (r *fakeRepository) GetNutrients(codes []string) ([]nutrient.Nutrient, error) {
+	return nil, nil
+}
+
+type fakeDiary struct {
+	diary.Service
+	totals diary.Totals
+}
+
+func (d fakeDiary) GetDaySummary(userID string, day time.Time) (*diary.DaySummary, error) {
+	return &diary.DaySummary{Date: day.Format(db.DateLayout), TimeZone: day.Location().String(), Totals: d.totals}, nil
+}
+
+func TestGetEffectiveWithoutGoal(t *testing.T) {
+	service := NewService(&fakeRepository{}, fakeDiary{})
+
+	_, err := service.GetEffective("user-1", db.NewDate(time.Now()))
+	if !errors.Is(err, ErrNoGoal) {
+		t.Errorf("GetEffective() = %v, want %v", err, ErrNoGoal)
+	}
+}
+
+func TestGetProgress(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	energy, share, fiber := 2000.0, 20.0, 30.0
+	repo := &fakeRepository{goal: &Goal{Targets: []Target{
+		{Nutrient: "energy_kcal", Type: TypeTarget, Amount: &energy},
+		{Nutrient: "protein_g", Type: TypeTarget, PercentEnergy: &share},
+		{Nutrient: "fiber_g", Type: TypeMin, Amount: &fiber},
+	}}}
+	intake := diary.Totals{Nutrition: food.Nutrition{EnergyKcal: 2300, ProteinG: 95, FiberG: 20}}
+	service := NewService(repo, fakeDiary{totals: intake})
+
+	// Local midnight in Kyiv is still the previous day in UTC.
+	progress, err := service.GetProgress("user-1", time.Date(2026, 3, 2, 0, 0, 0, 0, kyiv))
+	if err != nil {
+		t.Fatalf("GetProgress() = %v", err)
+	}
+	if len(repo.dates) != 1 || repo.dates[0] != "2026-03-02" {
+		t.Errorf("GetProgress() looked up goals of %v, want [2026-03-02]", repo.dates)
+	}
+
+	want := []struct {
+		target float64
+		status string
+	}{
+		{2000, StatusOver},
+		{100, StatusMet},
+		{30, StatusUnder},
+	}
+	if len(progress.Nutrients) != len(want) {
+		t.Fatalf("GetProgress() nutrients = %+v, want %d", progress.Nutrients, len(want))
+	}
+	for i, w := range want {
+		got := progress.Nutrients[i]
+		if got.Target != w.target || got.Status != w.status {
+			t.Errorf("GetProgress() %s = %v %s, want %v %s", got.Nutrient, got.Target, got.Status, w.target, w.status)
+		}
+	}
+}
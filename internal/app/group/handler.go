@@ -6,6 +6,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"net/http"
 	"strconv"
 )
@@ -85,6 +86,11 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.Service.Create(&group); err != nil {
+		if err == ErrParentNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Parent group not found")
+			h.Logger.Warn("Parent group not found", zap.Stringp("parent_id", group.ParentID))
+			return
+		}
 		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating group")
 		h.Logger.Error("Error creating group", zap.Error(err))
 		return
@@ -152,6 +158,16 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 			h.Logger.Warn("Group not found", zap.String("id", id))
 			return
 		}
+		if err == ErrParentNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Parent group not found")
+			h.Logger.Warn("Parent group not found", zap.String("id", id), zap.Stringp("parent_id", updatedData.ParentID))
+			return
+		}
+		if err == ErrGroupCycle {
+			errors.WriteHTTPError(w, http.StatusConflict, "Parent group would create a cycle")
+			h.Logger.Warn("Parent group would create a cycle", zap.String("id", id), zap.Stringp("parent_id", updatedData.ParentID))
+			return
+		}
 		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating group")
 		h.Logger.Error("Error updating group", zap.String("id", id), zap.Error(err))
 		return
@@ -177,6 +193,11 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 			h.Logger.Warn("Group not found", zap.String("id", id))
 			return
 		}
+		if err == ErrGroupHasChildren {
+			errors.WriteHTTPError(w, http.StatusConflict, "Group still has subgroups")
+			h.Logger.Warn("Group still has subgroups", zap.String("id", id))
+			return
+		}
 		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting group")
 		h.Logger.Error("Error deleting group", zap.String("id", id), zap.Error(err))
 		return
@@ -185,3 +206,268 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	h.Logger.Info("Deleted group", zap.String("id", id))
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *Handler) GetTree(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing group ID")
+		h.Logger.Warn("Missing group ID in request")
+		return
+	}
+
+	tree, err := h.Service.GetTree(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Group not found")
+			h.Logger.Warn("Group not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving group tree")
+		h.Logger.Error("Error retrieving group tree", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved group tree", zap.String("id", id))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tree); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetAncestors(w http.ResponseWriter, r *http.Request) {
+	h.writeRelatives(w, r, "ancestors", h.Service.GetAncestors)
+}
+
+func (h *Handler) GetDescendants(w http.ResponseWriter, r *http.Request) {
+	h.writeRelatives(w, r, "descendants", h.Service.GetDescendants)
+}
+
+func (h *Handler) writeRelatives(w http.ResponseWriter, r *http.Request, relation string, get func(id string) ([]Group, error)) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing group ID")
+		h.Logger.Warn("Missing group ID in request")
+		return
+	}
+
+	groups, err := get(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Group not found")
+			h.Logger.Warn("Group not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving group "+relation)
+		h.Logger.Error("Error retrieving group "+relation, zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved group "+relation, zap.String("id", id), zap.Int("returned", len(groups)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(groups); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetFoods(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing group ID")
+		h.Logger.Warn("Missing group ID in request")
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	includeDescendants := false
+	if d := r.URL.Query().Get("include_descendants"); d != "" {
+		parsed, err := strconv.ParseBool(d)
+		if err != nil {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'include_descendants' parameter")
+			h.Logger.Warn("Invalid 'include_descendants' parameter", zap.String("include_descendants", d))
+			return
+		}
+		includeDescendants = parsed
+	}
+
+	memberships, total, err := h.Service.GetFoods(id, includeDescendants, limit, offset)
+	if err != nil {
+		if err == ErrGroupNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Group not found")
+			h.Logger.Warn("Group not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving group foods")
+		h.Logger.Error("Error retrieving group foods", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     memberships,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(memberships),
+	}
+
+	h.Logger.Info("Retrieved group foods", zap.String("id", id), zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(memberships)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) AddFood(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing group ID")
+		h.Logger.Warn("Missing group ID in request")
+		return
+	}
+
+	var membership Membership
+	if err := json.NewDecoder(r.Body).Decode(&membership); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(membership); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	membership.GroupID = id
+	if err := h.Service.AddFood(&membership); err != nil {
+		h.writeMembershipError(w, err, id, membership.FoodID, "Error adding food to group")
+		return
+	}
+
+	h.Logger.Info("Added food to group", zap.String("id", id), zap.String("food_id", membership.FoodID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(membership); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateFood(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	foodID := chi.URLParam(r, "foodId")
+	if id == "" || foodID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing group or food ID")
+		h.Logger.Warn("Missing group or food ID in request")
+		return
+	}
+
+	var membership Membership
+	if err := json.NewDecoder(r.Body).Decode(&membership); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	membership.GroupID = id
+	membership.FoodID = foodID
+	if err := h.Validator.Struct(membership); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.UpdateFood(&membership); err != nil {
+		h.writeMembershipError(w, err, id, foodID, "Error updating group food")
+		return
+	}
+
+	h.Logger.Info("Updated group food", zap.String("id", id), zap.String("food_id", foodID), zap.Float64("max_size", membership.MaxSize))
+	if err := json.NewEncoder(w).Encode(membership); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) RemoveFood(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	foodID := chi.URLParam(r, "foodId")
+	if id == "" || foodID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing group or food ID")
+		h.Logger.Warn("Missing group or food ID in request")
+		return
+	}
+
+	if err := h.Service.RemoveFood(id, foodID); err != nil {
+		h.writeMembershipError(w, err, id, foodID, "Error removing food from group")
+		return
+	}
+
+	h.Logger.Info("Removed food from group", zap.String("id", id), zap.String("food_id", foodID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetFoodGroups(w http.ResponseWriter, r *http.Request) {
+	foodID := chi.URLParam(r, "id")
+	if foodID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food ID")
+		h.Logger.Warn("Missing food ID in request")
+		return
+	}
+
+	memberships, err := h.Service.GetFoodGroups(foodID)
+	if err != nil {
+		if err == ErrFoodNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("food_id", foodID))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving food groups")
+		h.Logger.Error("Error retrieving food groups", zap.String("food_id", foodID), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved food groups", zap.String("food_id", foodID), zap.Int("returned", len(memberships)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(memberships); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeMembershipError(w http.ResponseWriter, err error, groupID, foodID, message string) {
+	switch err {
+	case ErrGroupNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Group not found")
+		h.Logger.Warn("Group not found", zap.String("id", groupID))
+	case ErrFoodNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+		h.Logger.Warn("Food not found", zap.String("food_id", foodID))
+	case ErrMembershipNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Food is not a member of this group")
+		h.Logger.Warn("Food is not a member of group", zap.String("id", groupID), zap.String("food_id", foodID))
+	case ErrMembershipExists:
+		errors.WriteHTTPError(w, http.StatusConflict, "Food is already a member of this group")
+		h.Logger.Warn("Duplicate group membership", zap.String("id", groupID), zap.String("food_id", foodID))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", groupID), zap.String("food_id", foodID), zap.Error(err))
+	}
+}
@@ -1,10 +1,37 @@
 package group
 
-import "time"
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+)
 
 type Group struct {
 	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	Name      string    `json:"name" gorm:"not null" validate:"required,min=1,max=50"`
+	ParentID  *string   `json:"parent_id" gorm:"type:uuid" validate:"omitempty,uuid"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
+
+// Tree is a group together with all of its subgroups.
+type Tree struct {
+	Group
+	Children []*Tree `json:"children"`
+}
+
+// Membership links a food to a group, backed by the foods_groups table.
+type Membership struct {
+	ID        string     `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	FoodID    string     `json:"food_id" gorm:"type:uuid;not null" validate:"required,uuid"`
+	GroupID   string     `json:"group_id" gorm:"type:uuid;not null"`
+	MaxSize   float64    `json:"max_size" gorm:"not null" validate:"required,gt=0"`
+	Food      *food.Food `json:"food,omitempty" validate:"-"`
+	Group     *Group     `json:"group,omitempty" validate:"-"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Membership) TableName() string {
+	return "foods_groups"
+}
@@ -1,15 +1,57 @@
 package group
 
 import (
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// maxDepth guards the recursive queries against runaway recursion should the
+// hierarchy ever contain a cycle despite the checks in the service.
+const maxDepth = 100
+
+const subtreeIDsSQL = `
+WITH RECURSIVE subtree AS (
+    SELECT id, 0 AS depth FROM groups WHERE id = ?
+    UNION ALL
+    SELECT g.id, s.depth + 1 FROM groups g JOIN subtree s ON g.parent_id = s.id WHERE s.depth < ?
+)
+SELECT id FROM subtree`
+
+const descendantsSQL = `
+WITH RECURSIVE descendants AS (
+    SELECT g.*, 1 AS depth FROM groups g WHERE g.parent_id = ?
+    UNION ALL
+    SELECT g.*, d.depth + 1 FROM groups g JOIN descendants d ON g.parent_id = d.id WHERE d.depth < ?
+)
+SELECT id, name, parent_id, created_at, updated_at FROM descendants ORDER BY depth, name`
+
+const ancestorsSQL = `
+WITH RECURSIVE ancestors AS (
+    SELECT g.*, 1 AS depth FROM groups g WHERE g.id = (SELECT parent_id FROM groups WHERE id = ?)
+    UNION ALL
+    SELECT g.*, a.depth + 1 FROM groups g JOIN ancestors a ON g.id = a.parent_id WHERE a.depth < ?
+)
+SELECT id, name, parent_id, created_at, updated_at FROM ancestors ORDER BY depth DESC`
+
 type Repository interface {
 	GetAll(limit, offset int) ([]Group, int64, error)
 	GetByID(id string) (*Group, error)
 	Create(group *Group) error
 	Update(group *Group) error
 	Delete(id string) error
+	CountChildren(id string) (int64, error)
+	GetAncestors(id string) ([]Group, error)
+	GetDescendants(id string) ([]Group, error)
+	IsInSubtree(rootID, id string) (bool, error)
+
+	FoodExists(foodID string) (bool, error)
+	GetMemberships(groupID string, includeDescendants bool, limit, offset int) ([]Membership, int64, error)
+	GetFoodMemberships(foodID string) ([]Membership, error)
+	GetMembership(groupID, foodID string) (*Membership, error)
+	CreateMembership(membership *Membership) error
+	UpdateMembership(membership *Membership) error
+	DeleteMembership(groupID, foodID string) error
 }
 
 type repositoryImpl struct {
@@ -54,3 +96,94 @@ func (r *repositoryImpl) Update(group *Group) error {
 func (r *repositoryImpl) Delete(id string) error {
 	return r.db.Delete(&Group{}, "id = ?", id).Error
 }
+
+func (r *repositoryImpl) CountChildren(id string) (int64, error) {
+	var count int64
+	err := r.db.Model(&Group{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// GetAncestors returns the ancestors of a group, starting from the root.
+func (r *repositoryImpl) GetAncestors(id string) ([]Group, error) {
+	var groups []Group
+	err := r.db.Raw(ancestorsSQL, id, maxDepth).Scan(&groups).Error
+	return groups, err
+}
+
+// GetDescendants returns all groups below a group, level by level.
+func (r *repositoryImpl) GetDescendants(id string) ([]Group, error) {
+	var groups []Group
+	err := r.db.Raw(descendantsSQL, id, maxDepth).Scan(&groups).Error
+	return groups, err
+}
+
+// IsInSubtree reports whether id is rootID itself or one of its descendants.
+func (r *repositoryImpl) IsInSubtree(rootID, id string) (bool, error) {
+	var count int64
+	err := r.db.Model(&Group{}).
+		Where("id = ? AND id IN ("+subtreeIDsSQL+")", id, rootID, maxDepth).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repositoryImpl) FoodExists(foodID string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&food.Food{}).Where("id = ?", foodID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetMemberships lists the foods of a group. With includeDescendants, a food in
+// several groups of the subtree is listed once, with its earliest membership.
+func (r *repositoryImpl) GetMemberships(groupID string, includeDescendants bool, limit, offset int) ([]Membership, int64, error) {
+	var memberships []Membership
+	var total int64
+
+	query := r.db.Model(&Membership{})
+	if includeDescendants {
+		firstPerFood := r.db.Model(&Membership{}).
+			Select("DISTINCT ON (food_id) id").
+			Where("group_id IN ("+subtreeIDsSQL+")", groupID, maxDepth).
+			Order("food_id, created_at, id")
+		query = query.Where("id IN (?)", firstPerFood)
+	} else {
+		query = query.Where("group_id = ?", groupID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Food").Order("created_at").Limit(limit).Offset(offset).Find(&memberships).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return memberships, total, nil
+}
+
+func (r *repositoryImpl) GetFoodMemberships(foodID string) ([]Membership, error) {
+	var memberships []Membership
+	err := r.db.Preload("Group").Where("food_id = ?", foodID).Order("created_at").Find(&memberships).Error
+	return memberships, err
+}
+
+func (r *repositoryImpl) GetMembership(groupID, foodID string) (*Membership, error) {
+	var membership Membership
+	if err := r.db.First(&membership, "group_id = ? AND food_id = ?", groupID, foodID).Error; err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+func (r *repositoryImpl) CreateMembership(membership *Membership) error {
+	return r.db.Omit(clause.Associations).Create(membership).Error
+}
+
+func (r *repositoryImpl) UpdateMembership(membership *Membership) error {
+	return r.db.Omit(clause.Associations).Save(membership).Error
+}
+
+func (r *repositoryImpl) DeleteMembership(groupID, foodID string) error {
+	return r.db.Delete(&Membership{}, "group_id = ? AND food_id = ?", groupID, foodID).Error
+}
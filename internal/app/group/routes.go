@@ -11,5 +11,24 @@ func (h *Handler) Routes() chi.Router {
 	r.Put("/{id}", h.Update)
 	r.Delete("/{id}", h.Delete)
 
+	r.Get("/{id}/tree", h.GetTree)
+	r.Get("/{id}/ancestors", h.GetAncestors)
+	r.Get("/{id}/descendants", h.GetDescendants)
+
+	r.Get("/{id}/foods", h.GetFoods)
+	r.Post("/{id}/foods", h.AddFood)
+	r.Put("/{id}/foods/{foodId}", h.UpdateFood)
+	r.Delete("/{id}/foods/{foodId}", h.RemoveFood)
+
+	return r
+}
+
+// FoodRoutes lists the groups of a single food. It is meant to be mounted under
+// a router that provides the food ID as the "id" URL parameter.
+func (h *Handler) FoodRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetFoodGroups)
+
 	return r
 }
@@ -1,11 +1,36 @@
 package group
 
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrGroupNotFound      = errors.New("group not found")
+	ErrParentNotFound     = errors.New("parent group not found")
+	ErrGroupCycle         = errors.New("parent group would create a cycle")
+	ErrGroupHasChildren   = errors.New("group has subgroups")
+	ErrFoodNotFound       = errors.New("food not found")
+	ErrMembershipExists   = errors.New("food is already a member of the group")
+	ErrMembershipNotFound = errors.New("food is not a member of the group")
+)
+
 type Service interface {
 	GetAll(limit, offset int) ([]Group, int64, error)
 	GetByID(id string) (*Group, error)
 	Create(group *Group) error
 	Update(group *Group) error
 	Delete(id string) error
+	GetTree(id string) (*Tree, error)
+	GetAncestors(id string) ([]Group, error)
+	GetDescendants(id string) ([]Group, error)
+
+	GetFoods(groupID string, includeDescendants bool, limit, offset int) ([]Membership, int64, error)
+	GetFoodGroups(foodID string) ([]Membership, error)
+	AddFood(membership *Membership) error
+	UpdateFood(membership *Membership) error
+	RemoveFood(groupID, foodID string) error
 }
 
 type serviceImpl struct {
@@ -29,6 +54,11 @@ func (s *serviceImpl) GetByID(id string) (*Group, error) {
 }
 
 func (s *serviceImpl) Create(group *Group) error {
+	if group.ParentID != nil {
+		if err := s.ensureParent(*group.ParentID); err != nil {
+			return err
+		}
+	}
 	return s.repo.Create(group)
 }
 
@@ -38,11 +68,180 @@ func (s *serviceImpl) Update(group *Group) error {
 		return err
 	}
 
+	if group.ParentID != nil {
+		if err := s.ensureParent(*group.ParentID); err != nil {
+			return err
+		}
+
+		// The new parent must not be the group itself or any of its descendants.
+		cycle, err := s.repo.IsInSubtree(group.ID, *group.ParentID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrGroupCycle
+		}
+	}
+
 	group.CreatedAt = existingGroup.CreatedAt
 
 	return s.repo.Update(group)
 }
 
 func (s *serviceImpl) Delete(id string) error {
+	children, err := s.repo.CountChildren(id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrGroupHasChildren
+	}
 	return s.repo.Delete(id)
 }
+
+func (s *serviceImpl) GetTree(id string) (*Tree, error) {
+	root, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants, err := s.repo.GetDescendants(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &Tree{Group: *root, Children: []*Tree{}}
+	nodes := map[string]*Tree{root.ID: tree}
+	// Descendants come level by level, so a parent is always seen before its children.
+	for _, group := range descendants {
+		node := &Tree{Group: group, Children: []*Tree{}}
+		nodes[group.ID] = node
+		if parent, ok := nodes[*group.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	return tree, nil
+}
+
+func (s *serviceImpl) GetAncestors(id string) ([]Group, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAncestors(id)
+}
+
+func (s *serviceImpl) GetDescendants(id string) ([]Group, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDescendants(id)
+}
+
+func (s *serviceImpl) GetFoods(groupID string, includeDescendants bool, limit, offset int) ([]Membership, int64, error) {
+	if err := s.ensureGroup(groupID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.GetMemberships(groupID, includeDescendants, limit, offset)
+}
+
+func (s *serviceImpl) GetFoodGroups(foodID string) ([]Membership, error) {
+	if err := s.ensureFood(foodID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFoodMemberships(foodID)
+}
+
+func (s *serviceImpl) AddFood(membership *Membership) error {
+	if err := s.ensureGroup(membership.GroupID); err != nil {
+		return err
+	}
+	if err := s.ensureFood(membership.FoodID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetMembership(membership.GroupID, membership.FoodID); err == nil {
+		return ErrMembershipExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := s.repo.CreateMembership(membership); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrMembershipExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) UpdateFood(membership *Membership) error {
+	existing, err := s.getMembership(membership.GroupID, membership.FoodID)
+	if err != nil {
+		return err
+	}
+
+	existing.MaxSize = membership.MaxSize
+	if err := s.repo.UpdateMembership(existing); err != nil {
+		return err
+	}
+
+	*membership = *existing
+	return nil
+}
+
+func (s *serviceImpl) RemoveFood(groupID, foodID string) error {
+	if _, err := s.getMembership(groupID, foodID); err != nil {
+		return err
+	}
+	return s.repo.DeleteMembership(groupID, foodID)
+}
+
+func (s *serviceImpl) getMembership(groupID, foodID string) (*Membership, error) {
+	if err := s.ensureGroup(groupID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureFood(foodID); err != nil {
+		return nil, err
+	}
+
+	membership, err := s.repo.GetMembership(groupID, foodID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMembershipNotFound
+		}
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (s *serviceImpl) ensureGroup(groupID string) error {
+	if _, err := s.repo.GetByID(groupID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrGroupNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) ensureParent(parentID string) error {
+	if _, err := s.repo.GetByID(parentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrParentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) ensureFood(foodID string) error {
+	exists, err := s.repo.FoodExists(foodID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrFoodNotFound
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package hydration
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(database *gorm.DB, logger *zap.Logger) *Handler {
+	repo := NewRepository(database)
+	service := NewService(repo)
+	validator := validator.New()
+	validator.RegisterCustomTypeFunc(db.ValidateValuer, db.Date{})
+	hydrationLogger := logger.Named("HydrationHandler")
+
+	return NewHandler(service, validator, hydrationLogger)
+}
@@ -0,0 +1,418 @@
+package hydration
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetLogs(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	logs, total, err := h.Service.GetLogs(userID, period, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving hydration logs")
+		h.Logger.Error("Error retrieving hydration logs", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     logs,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(logs),
+	}
+
+	h.Logger.Info("Retrieved hydration logs", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(logs)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateLog(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var log Log
+	if err := json.NewDecoder(r.Body).Decode(&log); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(log); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateLog(userID, &log); err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating hydration log")
+		h.Logger.Error("Error creating hydration log", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Created hydration log", zap.String("id", log.ID), zap.Float64("volume_ml", log.VolumeMl))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(log); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetLogByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing hydration log ID")
+		h.Logger.Warn("Missing hydration log ID in request")
+		return
+	}
+
+	log, err := h.Service.GetLogByID(userID, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Hydration log not found")
+			h.Logger.Warn("Hydration log not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving hydration log")
+		h.Logger.Error("Error retrieving hydration log", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved hydration log", zap.String("id", log.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(log); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateLog(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing hydration log ID")
+		h.Logger.Warn("Missing hydration log ID in request")
+		return
+	}
+
+	var updatedData Log
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateLog(userID, &updatedData); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Hydration log not found")
+			h.Logger.Warn("Hydration log not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating hydration log")
+		h.Logger.Error("Error updating hydration log", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Updated hydration log", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteLog(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing hydration log ID")
+		h.Logger.Warn("Missing hydration log ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteLog(userID, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Hydration log not found")
+			h.Logger.Warn("Hydration log not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting hydration log")
+		h.Logger.Error("Error deleting hydration log", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Deleted hydration log", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetTargets(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	targets, total, err := h.Service.GetTargets(userID, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving hydration targets")
+		h.Logger.Error("Error retrieving hydration targets", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     targets,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(targets),
+	}
+
+	h.Logger.Info("Retrieved hydration targets", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(targets)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateTarget(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var target Target
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(target); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateTarget(userID, &target); err != nil {
+		if err == ErrTargetExists {
+			errors.WriteHTTPError(w, http.StatusConflict, "A hydration target already starts on this date")
+			h.Logger.Warn("Duplicate hydration target start date", zap.Stringer("effective_from", target.EffectiveFrom))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating hydration target")
+		h.Logger.Error("Error creating hydration target", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Created hydration target", zap.String("id", target.ID), zap.Stringer("effective_from", target.EffectiveFrom))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(target); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+// GetCurrentTarget returns the target in effect on the optional "date", today by default.
+func (h *Handler) GetCurrentTarget(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	date := db.NewDate(time.Now().In(location))
+	if value := r.URL.Query().Get("date"); value != "" {
+		parsed, err := db.ParseDate(value)
+		if err != nil {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'date' parameter, expected YYYY-MM-DD")
+			h.Logger.Warn("Invalid 'date' parameter", zap.String("date", value))
+			return
+		}
+		date = parsed
+	}
+
+	target, err := h.Service.GetEffectiveTarget(userID, date)
+	if err != nil {
+		if err == ErrNoTarget {
+			errors.WriteHTTPError(w, http.StatusNotFound, "No hydration target in effect on this date")
+			h.Logger.Warn("No hydration target in effect", zap.Stringer("date", date))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving hydration target")
+		h.Logger.Error("Error retrieving hydration target", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved current hydration target", zap.String("id", target.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(target); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteTarget(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing hydration target ID")
+		h.Logger.Warn("Missing hydration target ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteTarget(userID, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Hydration target not found")
+			h.Logger.Warn("Hydration target not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting hydration target")
+		h.Logger.Error("Error deleting hydration target", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Deleted hydration target", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetDay(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	date := chi.URLParam(r, "date")
+	parsed, err := db.ParseDate(date)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+		h.Logger.Warn("Invalid date", zap.String("date", date))
+		return
+	}
+
+	day, err := h.Service.GetDay(userID, parsed.In(location))
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating hydration")
+		h.Logger.Error("Error calculating hydration", zap.String("date", date), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Calculated hydration", zap.String("date", date), zap.Float64("total_ml", day.TotalMl))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(day); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
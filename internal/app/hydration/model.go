@@ -0,0 +1,49 @@
+package hydration
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+)
+
+// Log is an amount of fluid drunk at one time.
+type Log struct {
+	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    string    `json:"-" gorm:"type:uuid;not null"`
+	LoggedAt  time.Time `json:"logged_at" gorm:"not null" validate:"required"`
+	VolumeMl  float64   `json:"volume_ml" gorm:"not null" validate:"required,gt=0,lte=10000"`
+	Note      string    `json:"note" gorm:"not null;default:''" validate:"max=200"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Log) TableName() string {
+	return "hydration_logs"
+}
+
+// Target is the daily fluid target from EffectiveFrom until the next target starts.
+type Target struct {
+	ID            string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string    `json:"-" gorm:"type:uuid;not null"`
+	EffectiveFrom db.Date   `json:"effective_from" gorm:"not null;uniqueIndex" validate:"required"`
+	VolumeMl      float64   `json:"volume_ml" gorm:"not null" validate:"required,gt=0,lte=20000"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Target) TableName() string {
+	return "hydration_targets"
+}
+
+// Day is the fluid intake of a day. FoodMl is the water in diary entries of
+// foods with a water factor, counting one gram of water as one millilitre.
+type Day struct {
+	Date        string   `json:"date"`
+	TimeZone    string   `json:"time_zone"`
+	LoggedMl    float64  `json:"logged_ml"`
+	FoodMl      float64  `json:"food_ml"`
+	TotalMl     float64  `json:"total_ml"`
+	TargetMl    *float64 `json:"target_ml"`
+	RemainingMl *float64 `json:"remaining_ml"`
+	Percent     *float64 `json:"percent"`
+}
@@ -0,0 +1,139 @@
+package hydration
+
+import (
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+)
+
+// intakeSQL sums the logged fluids and the water of logged beverages in a range.
+const intakeSQL = `
+SELECT (SELECT COALESCE(SUM(volume_ml), 0)
+        FROM hydration_logs
+        WHERE user_id = ?
+          AND logged_at >= ?
+          AND logged_at < ?)                          AS logged_ml,
+       (SELECT COALESCE(SUM(e.grams * f.water_factor), 0)
+        FROM diary_entries e
+                 JOIN foods f ON f.id = e.food_id
+        WHERE e.user_id = ?
+          AND f.water_factor IS NOT NULL
+          AND e.consumed_at >= ?
+          AND e.consumed_at < ?)                      AS food_ml`
+
+type Repository interface {
+	GetLogs(userID string, period reporting.Range, limit, offset int) ([]Log, int64, error)
+	GetLogByID(userID, id string) (*Log, error)
+	CreateLog(log *Log) error
+	UpdateLog(log *Log) error
+	DeleteLog(userID, id string) error
+
+	GetTargets(userID string, limit, offset int) ([]Target, int64, error)
+	GetTargetByID(userID, id string) (*Target, error)
+	GetEffectiveTarget(userID string, date db.Date) (*Target, error)
+	CreateTarget(target *Target) error
+	DeleteTarget(userID, id string) error
+
+	GetIntake(userID string, period reporting.Range) (loggedMl, foodMl float64, err error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetLogs(userID string, period reporting.Range, limit, offset int) ([]Log, int64, error) {
+	var logs []Log
+	var total int64
+
+	query := r.db.Model(&Log{}).Where("user_id = ?", userID)
+	if !period.From.IsZero() {
+		query = query.Where("logged_at >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where("logged_at < ?", period.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("logged_at DESC").Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+func (r *repositoryImpl) GetLogByID(userID, id string) (*Log, error) {
+	var log Log
+	if err := r.db.First(&log, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (r *repositoryImpl) CreateLog(log *Log) error {
+	return r.db.Create(log).Error
+}
+
+func (r *repositoryImpl) UpdateLog(log *Log) error {
+	return r.db.Save(log).Error
+}
+
+func (r *repositoryImpl) DeleteLog(userID, id string) error {
+	return r.db.Delete(&Log{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetTargets(userID string, limit, offset int) ([]Target, int64, error) {
+	var targets []Target
+	var total int64
+
+	query := r.db.Model(&Target{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("effective_from DESC").Limit(limit).Offset(offset).Find(&targets).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return targets, total, nil
+}
+
+func (r *repositoryImpl) GetTargetByID(userID, id string) (*Target, error) {
+	var target Target
+	if err := r.db.First(&target, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// GetEffectiveTarget returns the target with the latest start on or before the date.
+func (r *repositoryImpl) GetEffectiveTarget(userID string, date db.Date) (*Target, error) {
+	var target Target
+	if err := r.db.Where("user_id = ? AND effective_from <= ?", userID, date).Order("effective_from DESC").First(&target).Error; err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (r *repositoryImpl) CreateTarget(target *Target) error {
+	return r.db.Create(target).Error
+}
+
+func (r *repositoryImpl) DeleteTarget(userID, id string) error {
+	return r.db.Delete(&Target{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetIntake(userID string, period reporting.Range) (float64, float64, error) {
+	var intake struct {
+		LoggedMl float64
+		FoodMl   float64
+	}
+	err := r.db.Raw(intakeSQL, userID, period.From, period.To, userID, period.From, period.To).Scan(&intake).Error
+	return intake.LoggedMl, intake.FoodMl, err
+}
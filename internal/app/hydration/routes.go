@@ -0,0 +1,22 @@
+package hydration
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/logs", h.GetLogs)
+	r.Post("/logs", h.CreateLog)
+	r.Get("/logs/{id}", h.GetLogByID)
+	r.Put("/logs/{id}", h.UpdateLog)
+	r.Delete("/logs/{id}", h.DeleteLog)
+
+	r.Get("/targets", h.GetTargets)
+	r.Post("/targets", h.CreateTarget)
+	r.Get("/targets/current", h.GetCurrentTarget)
+	r.Delete("/targets/{id}", h.DeleteTarget)
+
+	r.Get("/days/{date}", h.GetDay)
+
+	return r
+}
@@ -0,0 +1,134 @@
+package hydration
+
+import (
+	"errors"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNoTarget     = errors.New("no hydration target in effect")
+	ErrTargetExists = errors.New("a hydration target already starts on this date")
+)
+
+type Service interface {
+	GetLogs(userID string, period reporting.Range, limit, offset int) ([]Log, int64, error)
+	GetLogByID(userID, id string) (*Log, error)
+	CreateLog(userID string, log *Log) error
+	UpdateLog(userID string, log *Log) error
+	DeleteLog(userID, id string) error
+
+	GetTargets(userID string, limit, offset int) ([]Target, int64, error)
+	GetEffectiveTarget(userID string, date db.Date) (*Target, error)
+	CreateTarget(userID string, target *Target) error
+	DeleteTarget(userID, id string) error
+
+	// GetDay sums the intake of the day starting at the given local midnight.
+	GetDay(userID string, day time.Time) (*Day, error)
+}
+
+type serviceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetLogs(userID string, period reporting.Range, limit, offset int) ([]Log, int64, error) {
+	return s.repo.GetLogs(userID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetLogByID(userID, id string) (*Log, error) {
+	return s.repo.GetLogByID(userID, id)
+}
+
+func (s *serviceImpl) CreateLog(userID string, log *Log) error {
+	log.UserID = userID
+	return s.repo.CreateLog(log)
+}
+
+func (s *serviceImpl) UpdateLog(userID string, log *Log) error {
+	existingLog, err := s.repo.GetLogByID(userID, log.ID)
+	if err != nil {
+		return err
+	}
+
+	log.UserID = userID
+	log.CreatedAt = existingLog.CreatedAt
+	return s.repo.UpdateLog(log)
+}
+
+func (s *serviceImpl) DeleteLog(userID, id string) error {
+	if _, err := s.repo.GetLogByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteLog(userID, id)
+}
+
+func (s *serviceImpl) GetTargets(userID string, limit, offset int) ([]Target, int64, error) {
+	return s.repo.GetTargets(userID, limit, offset)
+}
+
+func (s *serviceImpl) GetEffectiveTarget(userID string, date db.Date) (*Target, error) {
+	target, err := s.repo.GetEffectiveTarget(userID, date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoTarget
+		}
+		return nil, err
+	}
+	return target, nil
+}
+
+func (s *serviceImpl) CreateTarget(userID string, target *Target) error {
+	target.UserID = userID
+	if err := s.repo.CreateTarget(target); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrTargetExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) DeleteTarget(userID, id string) error {
+	if _, err := s.repo.GetTargetByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteTarget(userID, id)
+}
+
+func (s *serviceImpl) GetDay(userID string, day time.Time) (*Day, error) {
+	loggedMl, foodMl, err := s.repo.GetIntake(userID, reporting.Range{From: day, To: day.AddDate(0, 0, 1)})
+	if err != nil {
+		return nil, err
+	}
+
+	date := db.NewDate(day)
+	result := &Day{
+		Date:     date.String(),
+		TimeZone: day.Location().String(),
+		LoggedMl: reporting.Round(loggedMl, 0),
+		FoodMl:   reporting.Round(foodMl, 0),
+		TotalMl:  reporting.Round(loggedMl+foodMl, 0),
+	}
+
+	target, err := s.GetEffectiveTarget(userID, date)
+	if err == ErrNoTarget {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := reporting.Round(target.VolumeMl-result.TotalMl, 0)
+	percent := reporting.Round(result.TotalMl/target.VolumeMl*100, 1)
+	result.TargetMl = &target.VolumeMl
+	result.RemainingMl = &remaining
+	result.Percent = &percent
+	return result, nil
+}
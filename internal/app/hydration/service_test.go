@@ -0,0 +1,91 @@
+package hydration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+)
+
+// fakeRepository returns fixed intake and target, and records what it was asked for.
+type fakeRepository struct {
+	Repository
+	loggedMl, foodMl float64
+	target           *Target
+	periods          []reporting.Range
+	dates            []string
+}
+
+func (r *fakeRepository) GetIntake(userID string, period reporting.Range) (float64, float64, error) {
+	r.periods = append(r.periods, period)
+	return r.loggedMl, r.foodMl, nil
+}
+
+func (r *fakeRepository) GetEffectiveTarget(userID string, date db.Date) (*Target, error) {
+	r.dates = append(r.dates, date.String())
+	if r.target == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.target, nil
+}
+
+func TestGetDay(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Local midnight in Kyiv is still the previous day in UTC.
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, kyiv)
+
+	tests := []struct {
+		name          string
+		target        *Target
+		wantRemaining float64
+		wantPercent   float64
+	}{
+		{"below target", &Target{VolumeMl: 2000}, 349, 82.6},
+		{"above target", &Target{VolumeMl: 1500}, -151, 110.1},
+		{"without target", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{loggedMl: 1250.4, foodMl: 400.3, target: tt.target}
+			service := NewService(repo)
+
+			got, err := service.GetDay("user-1", day)
+			if err != nil {
+				t.Fatalf("GetDay() = %v", err)
+			}
+
+			wantPeriod := reporting.Range{From: day, To: time.Date(2026, 3, 3, 0, 0, 0, 0, kyiv)}
+			if len(repo.periods) != 1 || repo.periods[0] != wantPeriod {
+				t.Errorf("GetDay() summed %v, want %v", repo.periods, wantPeriod)
+			}
+			if len(repo.dates) != 1 || repo.dates[0] != "2026-03-02" {
+				t.Errorf("GetDay() looked up targets of %v, want [2026-03-02]", repo.dates)
+			}
+			if got.Date != "2026-03-02" || got.LoggedMl != 1250 || got.FoodMl != 400 || got.TotalMl != 1651 {
+				t.Errorf("GetDay() = %+v, want 1250 + 400 = 1651 ml on 2026-03-02", got)
+			}
+
+			if tt.target == nil {
+				if got.TargetMl != nil || got.RemainingMl != nil || got.Percent != nil {
+					t.Errorf("GetDay() without target = %+v, want no target fields", got)
+				}
+				return
+			}
+			if got.TargetMl == nil || *got.TargetMl != tt.target.VolumeMl {
+				t.Errorf("GetDay() target = %v, want %v", got.TargetMl, tt.target.VolumeMl)
+			}
+			if got.RemainingMl == nil || *got.RemainingMl != tt.wantRemaining {
+				t.Errorf("GetDay() remaining = %v, want %v", got.RemainingMl, tt.wantRemaining)
+			}
+			if got.Percent == nil || *got.Percent != tt.wantPercent {
+				t.Errorf("GetDay() percent = %v, want %v", got.Percent, tt.wantPercent)
+			}
+		})
+	}
+}
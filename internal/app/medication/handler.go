@@ -0,0 +1,553 @@
+package medication
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultScheduleDays is the length of a schedule requested without "to".
+const defaultScheduleDays = 7
+
+// defaultAdherenceDays is the length of an adherence report requested without "from".
+const defaultAdherenceDays = 30
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	filter := Filter{
+		Query: r.URL.Query().Get("q"),
+		Kind:  r.URL.Query().Get("kind"),
+	}
+	if len(filter.Query) > 100 {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'q' parameter")
+		h.Logger.Warn("Search query too long", zap.Int("length", len(filter.Query)))
+		return
+	}
+	switch filter.Kind {
+	case "", KindMedication, KindSupplement:
+	default:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'kind' parameter")
+		h.Logger.Warn("Invalid 'kind' parameter", zap.String("kind", filter.Kind))
+		return
+	}
+
+	medications, total, err := h.Service.GetAll(userID, filter, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving medications")
+		h.Logger.Error("Error retrieving medications", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     medications,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(medications),
+	}
+
+	h.Logger.Info("Retrieved medications", zap.String("q", filter.Query), zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(medications)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var medication Medication
+	if err := json.NewDecoder(r.Body).Decode(&medication); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(medication); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(userID, &medication); err != nil {
+		h.writeError(w, err, "", "", "Error creating medication")
+		return
+	}
+
+	h.Logger.Info("Created new medication", zap.String("id", medication.ID), zap.String("name", medication.Name))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(medication); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+
+	medication, err := h.Service.GetByID(userID, id)
+	if err != nil {
+		h.writeError(w, err, id, "", "Error retrieving medication")
+		return
+	}
+
+	h.Logger.Info("Retrieved medication", zap.String("id", medication.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(medication); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+
+	var updatedData Medication
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(userID, &updatedData); err != nil {
+		h.writeError(w, err, id, "", "Error updating medication")
+		return
+	}
+
+	h.Logger.Info("Updated medication", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(userID, id); err != nil {
+		h.writeError(w, err, id, "", "Error deleting medication")
+		return
+	}
+
+	h.Logger.Info("Deleted medication", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+	if period.From.IsZero() {
+		now := time.Now().In(location)
+		period.From = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	}
+	if period.To.IsZero() {
+		period.To = period.From.AddDate(0, 0, defaultScheduleDays)
+	}
+
+	slots, err := h.Service.GetSchedule(userID, id, period)
+	if err != nil {
+		h.writeError(w, err, id, "", "Error retrieving medication schedule")
+		return
+	}
+
+	response := map[string]interface{}{
+		"data": slots,
+		"from": period.From,
+		"to":   period.To,
+	}
+
+	h.Logger.Info("Retrieved medication schedule", zap.String("id", id), zap.Int("slots", len(slots)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetDoses(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	doses, total, err := h.Service.GetDoses(userID, id, period, limit, offset)
+	if err != nil {
+		h.writeError(w, err, id, "", "Error retrieving doses")
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     doses,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(doses),
+	}
+
+	h.Logger.Info("Retrieved doses", zap.String("medication_id", id), zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(doses)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateDose(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+
+	var dose Dose
+	if err := json.NewDecoder(r.Body).Decode(&dose); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(dose); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	dose.ID = ""
+	dose.MedicationID = id
+	if err := h.Service.CreateDose(userID, &dose); err != nil {
+		h.writeError(w, err, id, "", "Error recording dose")
+		return
+	}
+
+	h.Logger.Info("Recorded dose", zap.String("medication_id", id), zap.String("id", dose.ID), zap.String("status", dose.Status))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(dose); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetDose(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+	doseID := chi.URLParam(r, "doseId")
+	if doseID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing dose ID")
+		h.Logger.Warn("Missing dose ID in request")
+		return
+	}
+
+	dose, err := h.Service.GetDose(userID, id, doseID)
+	if err != nil {
+		h.writeError(w, err, id, doseID, "Error retrieving dose")
+		return
+	}
+
+	h.Logger.Info("Retrieved dose", zap.String("medication_id", id), zap.String("id", dose.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dose); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateDose(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+	doseID := chi.URLParam(r, "doseId")
+	if doseID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing dose ID")
+		h.Logger.Warn("Missing dose ID in request")
+		return
+	}
+
+	var updatedData Dose
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = doseID
+	updatedData.MedicationID = id
+	if err := h.Service.UpdateDose(userID, &updatedData); err != nil {
+		h.writeError(w, err, id, doseID, "Error updating dose")
+		return
+	}
+
+	h.Logger.Info("Updated dose", zap.String("medication_id", id), zap.String("id", updatedData.ID), zap.String("status", updatedData.Status))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteDose(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing medication ID")
+		h.Logger.Warn("Missing medication ID in request")
+		return
+	}
+	doseID := chi.URLParam(r, "doseId")
+	if doseID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing dose ID")
+		h.Logger.Warn("Missing dose ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteDose(userID, id, doseID); err != nil {
+		h.writeError(w, err, id, doseID, "Error deleting dose")
+		return
+	}
+
+	h.Logger.Info("Deleted dose", zap.String("medication_id", id), zap.String("id", doseID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetAdherence(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+	if period.To.IsZero() {
+		now := time.Now().In(location)
+		period.To = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, location)
+	}
+	if period.From.IsZero() {
+		period.From = period.To.AddDate(0, 0, -defaultAdherenceDays)
+	}
+
+	report, err := h.Service.GetAdherence(userID, period)
+	if err != nil {
+		h.writeError(w, err, "", "", "Error calculating adherence")
+		return
+	}
+
+	h.Logger.Info("Calculated adherence", zap.Time("from", period.From), zap.Time("to", period.To), zap.Int("scheduled", report.Overall.Scheduled))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error, medicationID, doseID, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound, ErrMedicationNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Medication not found")
+		h.Logger.Warn("Medication not found", zap.String("id", medicationID))
+	case ErrDoseNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Dose not found")
+		h.Logger.Warn("Dose not found", zap.String("medication_id", medicationID), zap.String("id", doseID))
+	case ErrDoseExists:
+		errors.WriteHTTPError(w, http.StatusConflict, "Dose already recorded for the scheduled time")
+		h.Logger.Warn("Duplicate dose", zap.String("medication_id", medicationID))
+	case ErrNotScheduled:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "No dose scheduled at 'scheduled_at'")
+		h.Logger.Warn("Dose not scheduled", zap.String("medication_id", medicationID))
+	case ErrInvalidSchedule:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid schedule: 'daily' needs times, 'weekdays' needs weekdays and times, 'interval' takes at most one start time")
+		h.Logger.Warn("Invalid schedule", zap.String("id", medicationID))
+	case ErrInvalidPeriod:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "'end_date' must not be before 'start_date'")
+		h.Logger.Warn("End date before start date", zap.String("id", medicationID))
+	case ErrRangeTooLong:
+		errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("reporting.Range must not exceed %d days", MaxScheduleDays))
+		h.Logger.Warn("Schedule range too long", zap.String("id", medicationID))
+	case ErrNutrientsNotAllowed:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Only supplements can list nutrients")
+		h.Logger.Warn("Nutrients on a medication", zap.String("id", medicationID))
+	case ErrNutrientNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Nutrient not found")
+		h.Logger.Warn("Nutrient not found", zap.String("id", medicationID))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("medication_id", medicationID), zap.String("id", doseID), zap.Error(err))
+	}
+}
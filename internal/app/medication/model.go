@@ -0,0 +1,129 @@
+package medication
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+)
+
+// Kinds of medications. Only supplements may list nutrients.
+const (
+	KindMedication = "medication"
+	KindSupplement = "supplement"
+)
+
+// Schedule types.
+const (
+	// ScheduleDaily repeats every day at Times.
+	ScheduleDaily = "daily"
+	// ScheduleInterval repeats every IntervalHours, starting on StartDate at the
+	// first of Times or at midnight.
+	ScheduleInterval = "interval"
+	// ScheduleWeekdays repeats at Times on Weekdays.
+	ScheduleWeekdays = "weekdays"
+)
+
+// Dose statuses.
+const (
+	StatusTaken   = "taken"
+	StatusSkipped = "skipped"
+)
+
+// Medication is a medication or supplement taken on a schedule between StartDate
+// and the optional EndDate, both inclusive. Times ("15:04") and dates are local
+// to TimeZone.
+type Medication struct {
+	ID            string         `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string         `json:"-" gorm:"type:uuid;not null"`
+	Name          string         `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	Kind          string         `json:"kind" gorm:"not null" validate:"required,oneof=medication supplement"`
+	Dose          float64        `json:"dose" gorm:"not null" validate:"required,gt=0,lte=100000"`
+	Unit          string         `json:"unit" gorm:"not null" validate:"required,max=20"`
+	Schedule      string         `json:"schedule" gorm:"not null" validate:"required,oneof=daily interval weekdays"`
+	Times         db.StringArray `json:"times" gorm:"type:text[];not null;default:'{}'" validate:"max=24,unique,dive,datetime=15:04"`
+	IntervalHours *int           `json:"interval_hours" validate:"required_if=Schedule interval,omitempty,min=1,max=168"`
+	Weekdays      db.StringArray `json:"weekdays" gorm:"type:text[];not null;default:'{}'" validate:"max=7,unique,dive,oneof=mon tue wed thu fri sat sun"`
+	TimeZone      string         `json:"time_zone" gorm:"not null;default:'UTC'" validate:"omitempty,timezone"`
+	StartDate     db.Date        `json:"start_date" gorm:"not null" validate:"required"`
+	EndDate       *db.Date       `json:"end_date"`
+	Notes         string         `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	Nutrients     []Nutrient     `json:"nutrients" gorm:"foreignKey:MedicationID" validate:"max=100,unique=NutrientID,dive"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Nutrient is the amount of a catalogue nutrient in one dose of a supplement,
+// in the nutrient's canonical unit.
+type Nutrient struct {
+	MedicationID string    `json:"medication_id" gorm:"type:uuid;primaryKey"`
+	NutrientID   string    `json:"nutrient_id" gorm:"type:uuid;primaryKey" validate:"required,uuid"`
+	Amount       float64   `json:"amount" gorm:"not null" validate:"gt=0"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Nutrient) TableName() string {
+	return "medication_nutrients"
+}
+
+// Dose records whether a scheduled dose was taken or skipped. TakenAt defaults
+// to ScheduledAt for taken doses and is empty for skipped ones.
+type Dose struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	MedicationID string     `json:"medication_id" gorm:"type:uuid;not null"`
+	ScheduledAt  time.Time  `json:"scheduled_at" gorm:"not null" validate:"required"`
+	Status       string     `json:"status" gorm:"not null" validate:"required,oneof=taken skipped"`
+	TakenAt      *time.Time `json:"taken_at"`
+	Note         string     `json:"note" gorm:"not null;default:''" validate:"max=200"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Dose) TableName() string {
+	return "medication_doses"
+}
+
+// Filter narrows medication queries. Empty fields match everything.
+type Filter struct {
+	Query string
+	Kind  string
+}
+
+// Slot is a scheduled dose time with the dose recorded for it, if any.
+type Slot struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Dose        *Dose     `json:"dose"`
+}
+
+// Adherence counts the scheduled doses of a medication in a period that are
+// already due. Missed doses were neither taken nor skipped; Percent is the
+// share taken, nil without due doses.
+type Adherence struct {
+	MedicationID string   `json:"medication_id"`
+	Name         string   `json:"name"`
+	Kind         string   `json:"kind"`
+	Scheduled    int      `json:"scheduled"`
+	Taken        int      `json:"taken"`
+	Skipped      int      `json:"skipped"`
+	Missed       int      `json:"missed"`
+	Percent      *float64 `json:"percent"`
+}
+
+// Report is the adherence of every medication active in a period, with the
+// counts over all of them in Overall.
+type Report struct {
+	From        time.Time   `json:"from"`
+	To          time.Time   `json:"to"`
+	Overall     Adherence   `json:"overall"`
+	Medications []Adherence `json:"medications"`
+}
+
+// NutrientIntake is the amount of a nutrient in the supplement doses taken in a
+// period, in the nutrient's canonical unit.
+type NutrientIntake struct {
+	NutrientID string  `json:"nutrient_id"`
+	Code       string  `json:"code"`
+	Name       string  `json:"name"`
+	Unit       string  `json:"unit"`
+	Amount     float64 `json:"amount"`
+}
@@ -0,0 +1,193 @@
+package medication
+
+import (
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// nutrientIntakeSQL sums the nutrients of the supplement doses taken in a range.
+const nutrientIntakeSQL = `
+SELECT n.id           AS nutrient_id,
+       n.code,
+       n.name,
+       n.unit,
+       SUM(mn.amount) AS amount
+FROM medication_doses d
+         JOIN medications m ON m.id = d.medication_id
+         JOIN medication_nutrients mn ON mn.medication_id = d.medication_id
+         JOIN nutrients n ON n.id = mn.nutrient_id
+WHERE m.user_id = ?
+  AND d.status = 'taken'
+  AND d.taken_at >= ?
+  AND d.taken_at < ?
+GROUP BY n.id, n.code, n.name, n.unit
+ORDER BY n.code`
+
+type Repository interface {
+	GetAll(userID string, filter Filter, limit, offset int) ([]Medication, int64, error)
+	GetByID(userID, id string) (*Medication, error)
+	Create(medication *Medication) error
+	Update(medication *Medication) error
+	Delete(userID, id string) error
+
+	// GetActive returns the medications whose start and end dates overlap the
+	// range. The dates are compared without time zones, so the range is widened
+	// by a day on either side and callers expand the schedules exactly.
+	GetActive(userID string, period reporting.Range) ([]Medication, error)
+
+	GetDoses(medicationID string, period reporting.Range, limit, offset int) ([]Dose, int64, error)
+	GetDose(medicationID, doseID string) (*Dose, error)
+	CreateDose(dose *Dose) error
+	UpdateDose(dose *Dose) error
+	DeleteDose(medicationID, doseID string) error
+
+	// GetDosesInRange returns the doses of all of the user's medications scheduled in the range.
+	GetDosesInRange(userID string, period reporting.Range) ([]Dose, error)
+	GetNutrientIntake(userID string, period reporting.Range) ([]NutrientIntake, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(userID string, filter Filter, limit, offset int) ([]Medication, int64, error) {
+	var medications []Medication
+	var total int64
+
+	query := r.db.Model(&Medication{}).Where("user_id = ?", userID)
+	if filter.Query != "" {
+		query = query.Where(`name ILIKE ? ESCAPE '\'`, db.ContainsPattern(filter.Query))
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Nutrients").Order("name, id").Limit(limit).Offset(offset).Find(&medications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return medications, total, nil
+}
+
+func (r *repositoryImpl) GetByID(userID, id string) (*Medication, error) {
+	var medication Medication
+	if err := r.db.Preload("Nutrients").First(&medication, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &medication, nil
+}
+
+// Create stores the medication together with its nutrients.
+func (r *repositoryImpl) Create(medication *Medication) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(medication).Error; err != nil {
+			return err
+		}
+		return createNutrients(tx, medication)
+	})
+}
+
+// Update saves the medication and replaces all of its nutrients.
+func (r *repositoryImpl) Update(medication *Medication) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(medication).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&Nutrient{}, "medication_id = ?", medication.ID).Error; err != nil {
+			return err
+		}
+		return createNutrients(tx, medication)
+	})
+}
+
+func (r *repositoryImpl) Delete(userID, id string) error {
+	return r.db.Delete(&Medication{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetActive(userID string, period reporting.Range) ([]Medication, error) {
+	var medications []Medication
+	err := r.db.Where("user_id = ? AND start_date < ? AND (end_date IS NULL OR end_date >= ?)", userID,
+		period.To.AddDate(0, 0, 1).Format(db.DateLayout), period.From.AddDate(0, 0, -1).Format(db.DateLayout)).
+		Order("name, id").
+		Find(&medications).Error
+	return medications, err
+}
+
+func (r *repositoryImpl) GetDoses(medicationID string, period reporting.Range, limit, offset int) ([]Dose, int64, error) {
+	var doses []Dose
+	var total int64
+
+	query := r.db.Model(&Dose{}).Where("medication_id = ?", medicationID)
+	if !period.From.IsZero() {
+		query = query.Where("scheduled_at >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where("scheduled_at < ?", period.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("scheduled_at DESC").Limit(limit).Offset(offset).Find(&doses).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return doses, total, nil
+}
+
+func (r *repositoryImpl) GetDose(medicationID, doseID string) (*Dose, error) {
+	var dose Dose
+	if err := r.db.First(&dose, "medication_id = ? AND id = ?", medicationID, doseID).Error; err != nil {
+		return nil, err
+	}
+	return &dose, nil
+}
+
+func (r *repositoryImpl) CreateDose(dose *Dose) error {
+	return r.db.Create(dose).Error
+}
+
+func (r *repositoryImpl) UpdateDose(dose *Dose) error {
+	return r.db.Save(dose).Error
+}
+
+func (r *repositoryImpl) DeleteDose(medicationID, doseID string) error {
+	return r.db.Delete(&Dose{}, "medication_id = ? AND id = ?", medicationID, doseID).Error
+}
+
+func (r *repositoryImpl) GetDosesInRange(userID string, period reporting.Range) ([]Dose, error) {
+	var doses []Dose
+	err := r.db.
+		Where("medication_id IN (?)", r.db.Model(&Medication{}).Select("id").Where("user_id = ?", userID)).
+		Where("scheduled_at >= ? AND scheduled_at < ?", period.From, period.To).
+		Order("scheduled_at, id").
+		Find(&doses).Error
+	return doses, err
+}
+
+func (r *repositoryImpl) GetNutrientIntake(userID string, period reporting.Range) ([]NutrientIntake, error) {
+	var intake []NutrientIntake
+	err := r.db.Raw(nutrientIntakeSQL, userID, period.From, period.To).Scan(&intake).Error
+	return intake, err
+}
+
+func createNutrients(tx *gorm.DB, medication *Medication) error {
+	if len(medication.Nutrients) == 0 {
+		return nil
+	}
+	for i := range medication.Nutrients {
+		medication.Nutrients[i].MedicationID = medication.ID
+	}
+	return tx.Create(&medication.Nutrients).Error
+}
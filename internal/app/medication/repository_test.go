@@ -0,0 +1,45 @@
+package medication
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db/dbtest"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+func TestRepositoryScopesDosesToUser(t *testing.T) {
+	database, recorder := dbtest.Open(t)
+	repo := NewRepository(database)
+	period := reporting.Range{From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name  string
+		scope string
+		call  func() error
+	}{
+		{name: "GetDosesInRange", scope: `medication_id IN (SELECT "id" FROM "medications" WHERE user_id = 'user-1')`, call: func() error {
+			_, err := repo.GetDosesInRange("user-1", period)
+			return err
+		}},
+		{name: "GetNutrientIntake", scope: "m.user_id = 'user-1'", call: func() error {
+			_, err := repo.GetNutrientIntake("user-1", period)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Statements = nil
+			// A dry run finds no rows, so only the statement is checked.
+			_ = tt.call()
+			if len(recorder.Statements) != 1 {
+				t.Fatalf("sent %d statements, want 1", len(recorder.Statements))
+			}
+			if !strings.Contains(recorder.Statements[0], tt.scope) {
+				t.Errorf("statement lacks %q: %s", tt.scope, recorder.Statements[0])
+			}
+		})
+	}
+}
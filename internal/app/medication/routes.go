@@ -0,0 +1,23 @@
+package medication
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetAll)
+	r.Post("/", h.Create)
+	r.Get("/adherence", h.GetAdherence)
+	r.Get("/{id}", h.GetByID)
+	r.Put("/{id}", h.Update)
+	r.Delete("/{id}", h.Delete)
+
+	r.Get("/{id}/schedule", h.GetSchedule)
+	r.Get("/{id}/doses", h.GetDoses)
+	r.Post("/{id}/doses", h.CreateDose)
+	r.Get("/{id}/doses/{doseId}", h.GetDose)
+	r.Put("/{id}/doses/{doseId}", h.UpdateDose)
+	r.Delete("/{id}/doses/{doseId}", h.DeleteDose)
+
+	return r
+}
@@ -0,0 +1,123 @@
+package medication
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// clockLayout is the format of scheduled times of day.
+const clockLayout = "15:04"
+
+// MaxScheduleDays bounds the periods schedules are expanded over.
+const MaxScheduleDays = 366
+
+var (
+	ErrInvalidSchedule = errors.New("schedule needs times, or weekdays and times, for its type")
+	ErrInvalidPeriod   = errors.New("end date before start date")
+	ErrRangeTooLong    = errors.New("period longer than MaxScheduleDays")
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// checkSchedule rejects schedules that can never be due and fills in defaults.
+func checkSchedule(medication *Medication) error {
+	if medication.TimeZone == "" {
+		medication.TimeZone = "UTC"
+	}
+	if medication.EndDate != nil && medication.EndDate.Before(medication.StartDate.Time) {
+		return ErrInvalidPeriod
+	}
+
+	switch medication.Schedule {
+	case ScheduleDaily:
+		if len(medication.Times) == 0 || len(medication.Weekdays) > 0 || medication.IntervalHours != nil {
+			return ErrInvalidSchedule
+		}
+	case ScheduleWeekdays:
+		if len(medication.Times) == 0 || len(medication.Weekdays) == 0 || medication.IntervalHours != nil {
+			return ErrInvalidSchedule
+		}
+	case ScheduleInterval:
+		if len(medication.Times) > 1 || len(medication.Weekdays) > 0 {
+			return ErrInvalidSchedule
+		}
+	}
+	return nil
+}
+
+// occurrences returns the scheduled dose times of a medication in [From, To),
+// limited to its start and end dates.
+func occurrences(medication *Medication, period reporting.Range) ([]time.Time, error) {
+	location, err := time.LoadLocation(medication.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	from := medication.StartDate.In(location)
+	if period.From.After(from) {
+		from = period.From
+	}
+	to := period.To
+	if medication.EndDate != nil {
+		if end := medication.EndDate.In(location).AddDate(0, 0, 1); end.Before(to) {
+			to = end
+		}
+	}
+	if !from.Before(to) {
+		return nil, nil
+	}
+	if to.Sub(from) > MaxScheduleDays*24*time.Hour {
+		return nil, ErrRangeTooLong
+	}
+
+	var times []time.Time
+	if medication.Schedule == ScheduleInterval {
+		anchor := medication.StartDate.In(location)
+		if len(medication.Times) > 0 {
+			anchor = atClock(anchor, medication.Times[0])
+		}
+		step := time.Duration(*medication.IntervalHours) * time.Hour
+		first := math.Max(0, math.Ceil(float64(from.Sub(anchor))/float64(step)))
+		for t := anchor.Add(time.Duration(first) * step); t.Before(to); t = t.Add(step) {
+			times = append(times, t)
+		}
+		return times, nil
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, day := range medication.Weekdays {
+		days[weekdays[day]] = true
+	}
+
+	local := from.In(location)
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if medication.Schedule == ScheduleWeekdays && !days[day.Weekday()] {
+			continue
+		}
+		for _, clock := range medication.Times {
+			if t := atClock(day, clock); !t.Before(from) && t.Before(to) {
+				times = append(times, t)
+			}
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+// atClock returns the time of day on the date of day, in day's location.
+func atClock(day time.Time, clock string) time.Time {
+	parsed, _ := time.Parse(clockLayout, clock)
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), 0, 0, day.Location())
+}
@@ -0,0 +1,166 @@
+package medication
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+func TestOccurrences(t *testing.T) {
+	utc := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	date := func(month time.Month, day int) db.Date {
+		return db.NewDate(utc(month, day, 0, 0))
+	}
+	end := date(3, 1)
+	interval := 8
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		medication Medication
+		period     reporting.Range
+		want       []time.Time
+		err        error
+	}{
+		{
+			name: "daily",
+			medication: Medication{
+				Schedule: ScheduleDaily, Times: []string{"20:00", "08:00"}, TimeZone: "UTC", StartDate: date(3, 1),
+			},
+			period: reporting.Range{From: utc(3, 1, 0, 0), To: utc(3, 3, 0, 0)},
+			want:   []time.Time{utc(3, 1, 8, 0), utc(3, 1, 20, 0), utc(3, 2, 8, 0), utc(3, 2, 20, 0)},
+		},
+		{
+			name: "range starting mid-day",
+			medication: Medication{
+				Schedule: ScheduleDaily, Times: []string{"08:00", "20:00"}, TimeZone: "UTC", StartDate: date(3, 1),
+			},
+			period: reporting.Range{From: utc(3, 1, 12, 0), To: utc(3, 2, 12, 0)},
+			want:   []time.Time{utc(3, 1, 20, 0), utc(3, 2, 8, 0)},
+		},
+		{
+			name: "end date is inclusive",
+			medication: Medication{
+				Schedule: ScheduleDaily, Times: []string{"08:00"}, TimeZone: "UTC", StartDate: date(2, 27), EndDate: &end,
+			},
+			period: reporting.Range{From: utc(3, 1, 0, 0), To: utc(3, 5, 0, 0)},
+			want:   []time.Time{utc(3, 1, 8, 0)},
+		},
+		{
+			name: "not started yet",
+			medication: Medication{
+				Schedule: ScheduleDaily, Times: []string{"08:00"}, TimeZone: "UTC", StartDate: date(3, 10),
+			},
+			period: reporting.Range{From: utc(3, 1, 0, 0), To: utc(3, 5, 0, 0)},
+		},
+		{
+			name: "weekdays",
+			medication: Medication{
+				Schedule: ScheduleWeekdays, Times: []string{"09:00"}, Weekdays: []string{"mon", "wed"},
+				TimeZone: "UTC", StartDate: date(3, 1),
+			},
+			period: reporting.Range{From: utc(3, 2, 0, 0), To: utc(3, 9, 0, 0)},
+			want:   []time.Time{utc(3, 2, 9, 0), utc(3, 4, 9, 0)},
+		},
+		{
+			name: "interval from an anchor time",
+			medication: Medication{
+				Schedule: ScheduleInterval, Times: []string{"06:00"}, IntervalHours: &interval,
+				TimeZone: "UTC", StartDate: date(3, 1),
+			},
+			period: reporting.Range{From: utc(3, 1, 12, 0), To: utc(3, 2, 0, 0)},
+			want:   []time.Time{utc(3, 1, 14, 0), utc(3, 1, 22, 0)},
+		},
+		{
+			name: "local time across a daylight saving change",
+			medication: Medication{
+				Schedule: ScheduleDaily, Times: []string{"08:00"}, TimeZone: "Europe/Berlin", StartDate: date(3, 1),
+			},
+			period: reporting.Range{
+				From: time.Date(2026, 3, 28, 0, 0, 0, 0, berlin),
+				To:   time.Date(2026, 3, 30, 0, 0, 0, 0, berlin),
+			},
+			want: []time.Time{utc(3, 28, 7, 0), utc(3, 29, 6, 0)},
+		},
+		{
+			name: "range too long",
+			medication: Medication{
+				Schedule: ScheduleDaily, Times: []string{"08:00"}, TimeZone: "UTC", StartDate: date(1, 1),
+			},
+			period: reporting.Range{From: utc(1, 1, 0, 0), To: utc(1, 1, 0, 0).AddDate(1, 0, 2)},
+			err:    ErrRangeTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := occurrences(&tt.medication, tt.period)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("occurrences() error = %v, want %v", err, tt.err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrences()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckSchedule(t *testing.T) {
+	interval := 12
+	end := db.NewDate(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	start := db.NewDate(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		medication Medication
+		err        error
+	}{
+		{name: "daily", medication: Medication{Schedule: ScheduleDaily, Times: []string{"08:00"}}},
+		{name: "daily without times", medication: Medication{Schedule: ScheduleDaily}, err: ErrInvalidSchedule},
+		{
+			name:       "daily with weekdays",
+			medication: Medication{Schedule: ScheduleDaily, Times: []string{"08:00"}, Weekdays: []string{"mon"}},
+			err:        ErrInvalidSchedule,
+		},
+		{
+			name:       "weekdays without weekdays",
+			medication: Medication{Schedule: ScheduleWeekdays, Times: []string{"08:00"}},
+			err:        ErrInvalidSchedule,
+		},
+		{name: "interval", medication: Medication{Schedule: ScheduleInterval, IntervalHours: &interval}},
+		{
+			name:       "interval with two times",
+			medication: Medication{Schedule: ScheduleInterval, IntervalHours: &interval, Times: []string{"08:00", "20:00"}},
+			err:        ErrInvalidSchedule,
+		},
+		{
+			name:       "end before start",
+			medication: Medication{Schedule: ScheduleDaily, Times: []string{"08:00"}, StartDate: start, EndDate: &end},
+			err:        ErrInvalidPeriod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkSchedule(&tt.medication); !errors.Is(err, tt.err) {
+				t.Errorf("checkSchedule() error = %v, want %v", err, tt.err)
+			}
+			if tt.medication.TimeZone != "UTC" {
+				t.Errorf("TimeZone = %q, want UTC", tt.medication.TimeZone)
+			}
+		})
+	}
+}
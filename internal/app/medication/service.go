@@ -0,0 +1,328 @@
+package medication
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+var (
+	ErrMedicationNotFound  = errors.New("medication not found")
+	ErrDoseNotFound        = errors.New("dose not found")
+	ErrDoseExists          = errors.New("dose already recorded for the scheduled time")
+	ErrNotScheduled        = errors.New("no dose scheduled at that time")
+	ErrNutrientsNotAllowed = errors.New("only supplements can list nutrients")
+	ErrNutrientNotFound    = errors.New("nutrient not found")
+)
+
+type Service interface {
+	GetAll(userID string, filter Filter, limit, offset int) ([]Medication, int64, error)
+	GetByID(userID, id string) (*Medication, error)
+	Create(userID string, medication *Medication) error
+	Update(userID string, medication *Medication) error
+	Delete(userID, id string) error
+
+	// GetSchedule lists the medication's scheduled doses in the range with the doses recorded for them.
+	GetSchedule(userID, id string, period reporting.Range) ([]Slot, error)
+
+	GetDoses(userID, medicationID string, period reporting.Range, limit, offset int) ([]Dose, int64, error)
+	GetDose(userID, medicationID, doseID string) (*Dose, error)
+	CreateDose(userID string, dose *Dose) error
+	UpdateDose(userID string, dose *Dose) error
+	DeleteDose(userID, medicationID, doseID string) error
+
+	// GetAdherence reports the adherence in the range, counting doses scheduled
+	// until now.
+	GetAdherence(userID string, period reporting.Range) (*Report, error)
+	// GetNutrientIntake sums the nutrients of the supplement doses taken in the range.
+	GetNutrientIntake(userID string, period reporting.Range) ([]NutrientIntake, error)
+}
+
+type serviceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetAll(userID string, filter Filter, limit, offset int) ([]Medication, int64, error) {
+	return s.repo.GetAll(userID, filter, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(userID, id string) (*Medication, error) {
+	return s.repo.GetByID(userID, id)
+}
+
+func (s *serviceImpl) Create(userID string, medication *Medication) error {
+	medication.UserID = userID
+	if err := check(medication); err != nil {
+		return err
+	}
+
+	return translate(s.repo.Create(medication))
+}
+
+func (s *serviceImpl) Update(userID string, medication *Medication) error {
+	existingMedication, err := s.repo.GetByID(userID, medication.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := check(medication); err != nil {
+		return err
+	}
+
+	medication.UserID = userID
+	medication.CreatedAt = existingMedication.CreatedAt
+	return translate(s.repo.Update(medication))
+}
+
+func (s *serviceImpl) Delete(userID, id string) error {
+	if _, err := s.repo.GetByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(userID, id)
+}
+
+func (s *serviceImpl) GetSchedule(userID, id string, period reporting.Range) ([]Slot, error) {
+	medication, err := s.repo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := occurrences(medication, period)
+	if err != nil {
+		return nil, err
+	}
+
+	// A limit of -1 lifts the limit.
+	doses, _, err := s.repo.GetDoses(id, period, -1, 0)
+	if err != nil {
+		return nil, err
+	}
+	recorded := map[int64]*Dose{}
+	for i := range doses {
+		recorded[doses[i].ScheduledAt.Unix()] = &doses[i]
+	}
+
+	slots := []Slot{}
+	for _, t := range times {
+		slots = append(slots, Slot{ScheduledAt: t, Dose: recorded[t.Unix()]})
+	}
+	return slots, nil
+}
+
+func (s *serviceImpl) GetDoses(userID, medicationID string, period reporting.Range, limit, offset int) ([]Dose, int64, error) {
+	if _, err := s.getMedication(userID, medicationID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.GetDoses(medicationID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetDose(userID, medicationID, doseID string) (*Dose, error) {
+	if _, err := s.getMedication(userID, medicationID); err != nil {
+		return nil, err
+	}
+	return s.getDose(medicationID, doseID)
+}
+
+func (s *serviceImpl) CreateDose(userID string, dose *Dose) error {
+	medication, err := s.getMedication(userID, dose.MedicationID)
+	if err != nil {
+		return err
+	}
+
+	if err := prepareDose(medication, dose); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateDose(dose); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrDoseExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) UpdateDose(userID string, dose *Dose) error {
+	medication, err := s.getMedication(userID, dose.MedicationID)
+	if err != nil {
+		return err
+	}
+	existingDose, err := s.getDose(dose.MedicationID, dose.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := prepareDose(medication, dose); err != nil {
+		return err
+	}
+
+	dose.CreatedAt = existingDose.CreatedAt
+	if err := s.repo.UpdateDose(dose); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrDoseExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) DeleteDose(userID, medicationID, doseID string) error {
+	if _, err := s.getMedication(userID, medicationID); err != nil {
+		return err
+	}
+	if _, err := s.getDose(medicationID, doseID); err != nil {
+		return err
+	}
+	return s.repo.DeleteDose(medicationID, doseID)
+}
+
+func (s *serviceImpl) GetAdherence(userID string, period reporting.Range) (*Report, error) {
+	due := period
+	if now := time.Now(); due.To.After(now) {
+		due.To = now
+	}
+
+	report := &Report{
+		From:        period.From,
+		To:          period.To,
+		Medications: []Adherence{},
+	}
+	if !due.From.Before(due.To) {
+		return report, nil
+	}
+
+	medications, err := s.repo.GetActive(userID, due)
+	if err != nil {
+		return nil, err
+	}
+
+	doses, err := s.repo.GetDosesInRange(userID, due)
+	if err != nil {
+		return nil, err
+	}
+	statuses := map[string]map[int64]string{}
+	for _, dose := range doses {
+		if statuses[dose.MedicationID] == nil {
+			statuses[dose.MedicationID] = map[int64]string{}
+		}
+		statuses[dose.MedicationID][dose.ScheduledAt.Unix()] = dose.Status
+	}
+
+	for i := range medications {
+		times, err := occurrences(&medications[i], due)
+		if err != nil {
+			return nil, err
+		}
+		if len(times) == 0 {
+			continue
+		}
+
+		adherence := Adherence{
+			MedicationID: medications[i].ID,
+			Name:         medications[i].Name,
+			Kind:         medications[i].Kind,
+		}
+		for _, t := range times {
+			adherence.Scheduled++
+			switch statuses[medications[i].ID][t.Unix()] {
+			case StatusTaken:
+				adherence.Taken++
+			case StatusSkipped:
+				adherence.Skipped++
+			default:
+				adherence.Missed++
+			}
+		}
+		adherence.Percent = percent(adherence.Taken, adherence.Scheduled)
+		report.Medications = append(report.Medications, adherence)
+
+		report.Overall.Scheduled += adherence.Scheduled
+		report.Overall.Taken += adherence.Taken
+		report.Overall.Skipped += adherence.Skipped
+		report.Overall.Missed += adherence.Missed
+	}
+	report.Overall.Percent = percent(report.Overall.Taken, report.Overall.Scheduled)
+
+	return report, nil
+}
+
+func (s *serviceImpl) GetNutrientIntake(userID string, period reporting.Range) ([]NutrientIntake, error) {
+	return s.repo.GetNutrientIntake(userID, period)
+}
+
+func (s *serviceImpl) getMedication(userID, id string) (*Medication, error) {
+	medication, err := s.repo.GetByID(userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMedicationNotFound
+		}
+		return nil, err
+	}
+	return medication, nil
+}
+
+func (s *serviceImpl) getDose(medicationID, doseID string) (*Dose, error) {
+	dose, err := s.repo.GetDose(medicationID, doseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDoseNotFound
+		}
+		return nil, err
+	}
+	return dose, nil
+}
+
+// check validates the schedule and the nutrients of a medication.
+func check(medication *Medication) error {
+	if err := checkSchedule(medication); err != nil {
+		return err
+	}
+	if medication.Kind != KindSupplement && len(medication.Nutrients) > 0 {
+		return ErrNutrientsNotAllowed
+	}
+	return nil
+}
+
+// prepareDose checks that the dose matches a scheduled time and sets its TakenAt.
+func prepareDose(medication *Medication, dose *Dose) error {
+	times, err := occurrences(medication, reporting.Range{From: dose.ScheduledAt, To: dose.ScheduledAt.Add(time.Second)})
+	if err != nil {
+		return err
+	}
+	if len(times) == 0 || !times[0].Equal(dose.ScheduledAt) {
+		return ErrNotScheduled
+	}
+
+	switch dose.Status {
+	case StatusTaken:
+		if dose.TakenAt == nil {
+			dose.TakenAt = &dose.ScheduledAt
+		}
+	case StatusSkipped:
+		dose.TakenAt = nil
+	}
+	return nil
+}
+
+// translate maps a missing nutrient reference to ErrNutrientNotFound.
+func translate(err error) error {
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return ErrNutrientNotFound
+	}
+	return err
+}
+
+func percent(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	value := reporting.Round(float64(part)/float64(whole)*100, 1)
+	return &value
+}
@@ -0,0 +1,165 @@
+package medication
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"gorm.io/gorm"
+)
+
+// fakeRepository serves the medications and doses adherence is computed from.
+// GetByID finds the medications for owner only, and the dose calls are recorded.
+type fakeRepository struct {
+	Repository
+	medications []Medication
+	doses       []Dose
+	owner       string
+	doseCalls   []string
+}
+
+func (r *fakeRepository) GetByID(userID, id string) (*Medication, error) {
+	for _, medication := range r.medications {
+		if medication.ID == id && userID == r.owner {
+			return &medication, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeRepository) GetDoses(medicationID string, period reporting.Range, limit, offset int) ([]Dose, int64, error) {
+	r.doseCalls = append(r.doseCalls, "GetDoses")
+	return nil, 0, nil
+}
+
+func (r *fakeRepository) GetDose(medicationID, doseID string) (*Dose, error) {
+	r.doseCalls = append(r.doseCalls, "GetDose")
+	return &Dose{ID: doseID, MedicationID: medicationID}, nil
+}
+
+func (r *fakeRepository) CreateDose(dose *Dose) error {
+	r.doseCalls = append(r.doseCalls, "CreateDose")
+	return nil
+}
+
+func (r *fakeRepository) UpdateDose(dose *Dose) error {
+	r.doseCalls = append(r.doseCalls, "UpdateDose")
+	return nil
+}
+
+func (r *fakeRepository) DeleteDose(medicationID, doseID string) error {
+	r.doseCalls = append(r.doseCalls, "DeleteDose")
+	return nil
+}
+
+func (r *fakeRepository) GetActive(userID string, period reporting.Range) ([]Medication, error) {
+	return r.medications, nil
+}
+
+func (r *fakeRepository) GetDosesInRange(userID string, period reporting.Range) ([]Dose, error) {
+	return r.doses, nil
+}
+
+func TestGetAdherence(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+	start := db.NewDate(at(1, 0))
+
+	repo := &fakeRepository{
+		medications: []Medication{
+			{ID: "vitamin-d", Name: "Vitamin D", Kind: KindSupplement, Schedule: ScheduleDaily,
+				Times: []string{"08:00", "20:00"}, TimeZone: "UTC", StartDate: start},
+			{ID: "later", Name: "Later", Kind: KindMedication, Schedule: ScheduleDaily,
+				Times: []string{"08:00"}, TimeZone: "UTC", StartDate: db.NewDate(at(10, 0))},
+		},
+		doses: []Dose{
+			{MedicationID: "vitamin-d", ScheduledAt: at(1, 8), Status: StatusTaken},
+			{MedicationID: "vitamin-d", ScheduledAt: at(1, 20), Status: StatusSkipped},
+			{MedicationID: "vitamin-d", ScheduledAt: at(2, 8), Status: StatusTaken},
+			{MedicationID: "vitamin-d", ScheduledAt: at(2, 9), Status: StatusTaken},
+		},
+	}
+	service := NewService(repo)
+
+	report, err := service.GetAdherence("user", reporting.Range{From: at(1, 0), To: at(3, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Medications) != 1 {
+		t.Fatalf("got %d medications, want only the one due in the range", len(report.Medications))
+	}
+	got := report.Medications[0]
+	if got.Scheduled != 4 || got.Taken != 2 || got.Skipped != 1 || got.Missed != 1 {
+		t.Errorf("got %d scheduled, %d taken, %d skipped, %d missed, want 4, 2, 1, 1",
+			got.Scheduled, got.Taken, got.Skipped, got.Missed)
+	}
+	if got.Percent == nil || *got.Percent != 50 {
+		t.Errorf("Percent = %v, want 50", got.Percent)
+	}
+	if report.Overall.Scheduled != 4 || report.Overall.Percent == nil || *report.Overall.Percent != 50 {
+		t.Errorf("Overall = %+v, want 4 scheduled at 50%%", report.Overall)
+	}
+}
+
+func TestGetAdherenceInTheFuture(t *testing.T) {
+	from := time.Now().Add(24 * time.Hour)
+	report, err := NewService(&fakeRepository{}).GetAdherence("user", reporting.Range{From: from, To: from.Add(24 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Medications) != 0 || report.Overall.Percent != nil {
+		t.Errorf("got %+v, want an empty report", report)
+	}
+}
+
+func TestDosesOfAnotherUsersMedication(t *testing.T) {
+	repo := &fakeRepository{
+		medications: []Medication{{ID: "vitamin-d", Schedule: ScheduleDaily, Times: []string{"08:00"}, TimeZone: "UTC",
+			StartDate: db.NewDate(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))}},
+		owner: "owner",
+	}
+	service := NewService(repo)
+	dose := func() *Dose {
+		return &Dose{ID: "dose-1", MedicationID: "vitamin-d", ScheduledAt: time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), Status: StatusSkipped}
+	}
+
+	tests := []struct {
+		name string
+		call func(userID string) error
+	}{
+		{name: "GetDoses", call: func(userID string) error {
+			_, _, err := service.GetDoses(userID, "vitamin-d", reporting.Range{}, 10, 0)
+			return err
+		}},
+		{name: "GetDose", call: func(userID string) error {
+			_, err := service.GetDose(userID, "vitamin-d", "dose-1")
+			return err
+		}},
+		{name: "CreateDose", call: func(userID string) error { return service.CreateDose(userID, dose()) }},
+		{name: "UpdateDose", call: func(userID string) error { return service.UpdateDose(userID, dose()) }},
+		{name: "DeleteDose", call: func(userID string) error { return service.DeleteDose(userID, "vitamin-d", "dose-1") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.doseCalls = nil
+			if err := tt.call("intruder"); !errors.Is(err, ErrMedicationNotFound) {
+				t.Fatalf("%s() by another user error = %v, want %v", tt.name, err, ErrMedicationNotFound)
+			}
+			if len(repo.doseCalls) > 0 {
+				t.Errorf("%s() by another user reached the doses: %v", tt.name, repo.doseCalls)
+			}
+
+			if err := tt.call("owner"); err != nil {
+				t.Fatalf("%s() by the owner error = %v", tt.name, err)
+			}
+			if len(repo.doseCalls) == 0 {
+				t.Errorf("%s() by the owner did not reach the doses", tt.name)
+			}
+		})
+	}
+}
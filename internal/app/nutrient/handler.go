@@ -0,0 +1,310 @@
+package nutrient
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	category := r.URL.Query().Get("category")
+	if category != "" {
+		if err := h.Validator.Var(category, "oneof=vitamin mineral amino_acid other"); err != nil {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'category' parameter")
+			h.Logger.Warn("Invalid 'category' parameter", zap.String("category", category))
+			return
+		}
+	}
+
+	nutrients, total, err := h.Service.GetAll(category, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving nutrients")
+		h.Logger.Error("Error retrieving nutrients", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     nutrients,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(nutrients),
+	}
+
+	h.Logger.Info("Retrieved nutrients", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(nutrients)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var nutrient Nutrient
+	if err := json.NewDecoder(r.Body).Decode(&nutrient); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(nutrient); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(&nutrient); err != nil {
+		if err == gorm.ErrDuplicatedKey {
+			errors.WriteHTTPError(w, http.StatusConflict, "Nutrient code already exists")
+			h.Logger.Warn("Nutrient code already exists", zap.String("code", nutrient.Code))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating nutrient")
+		h.Logger.Error("Error creating nutrient", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Created new nutrient", zap.String("id", nutrient.ID), zap.String("code", nutrient.Code))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(nutrient); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing nutrient ID")
+		h.Logger.Warn("Missing nutrient ID in request")
+		return
+	}
+
+	nutrient, err := h.Service.GetByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Nutrient not found")
+			h.Logger.Warn("Nutrient not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving nutrient")
+		h.Logger.Error("Error retrieving nutrient", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved nutrient", zap.String("id", nutrient.ID), zap.String("code", nutrient.Code))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nutrient); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing nutrient ID")
+		h.Logger.Warn("Missing nutrient ID in request")
+		return
+	}
+
+	var updatedData Nutrient
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(&updatedData); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Nutrient not found")
+			h.Logger.Warn("Nutrient not found", zap.String("id", id))
+		case gorm.ErrDuplicatedKey:
+			errors.WriteHTTPError(w, http.StatusConflict, "Nutrient code already exists")
+			h.Logger.Warn("Nutrient code already exists", zap.String("code", updatedData.Code))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating nutrient")
+			h.Logger.Error("Error updating nutrient", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Updated nutrient", zap.String("id", updatedData.ID), zap.String("code", updatedData.Code))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing nutrient ID")
+		h.Logger.Warn("Missing nutrient ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(id); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Nutrient not found")
+			h.Logger.Warn("Nutrient not found", zap.String("id", id))
+		case ErrNutrientInUse:
+			errors.WriteHTTPError(w, http.StatusConflict, "Nutrient is still referenced by foods")
+			h.Logger.Warn("Nutrient is still referenced by foods", zap.String("id", id))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting nutrient")
+			h.Logger.Error("Error deleting nutrient", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Deleted nutrient", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetFoodProfile(w http.ResponseWriter, r *http.Request) {
+	foodID := chi.URLParam(r, "id")
+	if foodID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food ID")
+		h.Logger.Warn("Missing food ID in request")
+		return
+	}
+
+	profile, err := h.Service.GetFoodProfile(foodID)
+	if err != nil {
+		if err == ErrFoodNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("food_id", foodID))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving nutrient profile")
+		h.Logger.Error("Error retrieving nutrient profile", zap.String("food_id", foodID), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved nutrient profile", zap.String("food_id", foodID), zap.Int("returned", len(profile)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(profile); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) SetFoodNutrient(w http.ResponseWriter, r *http.Request) {
+	foodID := chi.URLParam(r, "id")
+	nutrientID := chi.URLParam(r, "nutrientId")
+	if foodID == "" || nutrientID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food or nutrient ID")
+		h.Logger.Warn("Missing food or nutrient ID in request")
+		return
+	}
+
+	var foodNutrient FoodNutrient
+	if err := json.NewDecoder(r.Body).Decode(&foodNutrient); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(foodNutrient); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	foodNutrient.FoodID = foodID
+	foodNutrient.NutrientID = nutrientID
+	if err := h.Service.SetFoodNutrient(&foodNutrient); err != nil {
+		switch err {
+		case ErrFoodNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("food_id", foodID))
+		case ErrNutrientNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Nutrient not found")
+			h.Logger.Warn("Nutrient not found", zap.String("nutrient_id", nutrientID))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error saving food nutrient")
+			h.Logger.Error("Error saving food nutrient", zap.String("food_id", foodID), zap.String("nutrient_id", nutrientID), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Saved food nutrient", zap.String("food_id", foodID), zap.String("nutrient_id", nutrientID), zap.Float64("amount", foodNutrient.Amount))
+	if err := json.NewEncoder(w).Encode(foodNutrient); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) RemoveFoodNutrient(w http.ResponseWriter, r *http.Request) {
+	foodID := chi.URLParam(r, "id")
+	nutrientID := chi.URLParam(r, "nutrientId")
+	if foodID == "" || nutrientID == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing food or nutrient ID")
+		h.Logger.Warn("Missing food or nutrient ID in request")
+		return
+	}
+
+	if err := h.Service.RemoveFoodNutrient(foodID, nutrientID); err != nil {
+		switch err {
+		case ErrFoodNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Food not found")
+			h.Logger.Warn("Food not found", zap.String("food_id", foodID))
+		case ErrNutrientNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Nutrient not set for this food")
+			h.Logger.Warn("Nutrient not set for food", zap.String("food_id", foodID), zap.String("nutrient_id", nutrientID))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error removing food nutrient")
+			h.Logger.Error("Error removing food nutrient", zap.String("food_id", foodID), zap.String("nutrient_id", nutrientID), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Removed food nutrient", zap.String("food_id", foodID), zap.String("nutrient_id", nutrientID))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,33 @@
+package nutrient
+
+import "time"
+
+// Nutrient categories.
+const (
+	CategoryVitamin   = "vitamin"
+	CategoryMineral   = "mineral"
+	CategoryAminoAcid = "amino_acid"
+	CategoryOther     = "other"
+)
+
+// Nutrient is an entry of the micronutrient catalogue. Amounts linked to foods
+// are always expressed in the nutrient's canonical Unit.
+type Nutrient struct {
+	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Code      string    `json:"code" gorm:"not null;uniqueIndex" validate:"required,min=1,max=50"`
+	Name      string    `json:"name" gorm:"not null" validate:"required,min=1,max=100"`
+	Category  string    `json:"category" gorm:"not null" validate:"required,oneof=vitamin mineral amino_acid other"`
+	Unit      string    `json:"unit" gorm:"not null" validate:"required,oneof=g mg mcg IU"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// FoodNutrient is the amount of a nutrient contained in 100 g of a food.
+type FoodNutrient struct {
+	FoodID     string    `json:"food_id" gorm:"type:uuid;primaryKey"`
+	NutrientID string    `json:"nutrient_id" gorm:"type:uuid;primaryKey"`
+	Amount     float64   `json:"amount" gorm:"not null" validate:"gte=0"`
+	Nutrient   *Nutrient `json:"nutrient,omitempty"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,103 @@
+package nutrient
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetAll(category string, limit, offset int) ([]Nutrient, int64, error)
+	GetByID(id string) (*Nutrient, error)
+	Create(nutrient *Nutrient) error
+	Update(nutrient *Nutrient) error
+	Delete(id string) error
+	CountFoods(nutrientID string) (int64, error)
+
+	FoodExists(foodID string) (bool, error)
+	GetFoodProfile(foodID string) ([]FoodNutrient, error)
+	UpsertFoodNutrient(foodNutrient *FoodNutrient) error
+	DeleteFoodNutrient(foodID, nutrientID string) (int64, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(category string, limit, offset int) ([]Nutrient, int64, error) {
+	var nutrients []Nutrient
+	var total int64
+
+	query := r.db.Model(&Nutrient{})
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("category, name").Limit(limit).Offset(offset).Find(&nutrients).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return nutrients, total, nil
+}
+
+func (r *repositoryImpl) GetByID(id string) (*Nutrient, error) {
+	var nutrient Nutrient
+	if err := r.db.First(&nutrient, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &nutrient, nil
+}
+
+func (r *repositoryImpl) Create(nutrient *Nutrient) error {
+	return r.db.Create(nutrient).Error
+}
+
+func (r *repositoryImpl) Update(nutrient *Nutrient) error {
+	return r.db.Save(nutrient).Error
+}
+
+func (r *repositoryImpl) Delete(id string) error {
+	return r.db.Delete(&Nutrient{}, "id = ?", id).Error
+}
+
+func (r *repositoryImpl) CountFoods(nutrientID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&FoodNutrient{}).Where("nutrient_id = ?", nutrientID).Count(&count).Error
+	return count, err
+}
+
+func (r *repositoryImpl) FoodExists(foodID string) (bool, error) {
+	var count int64
+	if err := r.db.Table("foods").Where("id = ?", foodID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *repositoryImpl) GetFoodProfile(foodID string) ([]FoodNutrient, error) {
+	var profile []FoodNutrient
+	err := r.db.Joins("Nutrient").
+		Where("food_nutrients.food_id = ?", foodID).
+		Order(`"Nutrient".category, "Nutrient".name`).
+		Find(&profile).Error
+	return profile, err
+}
+
+func (r *repositoryImpl) UpsertFoodNutrient(foodNutrient *FoodNutrient) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "food_id"}, {Name: "nutrient_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount", "updated_at"}),
+	}).Omit("Nutrient").Create(foodNutrient).Error
+}
+
+func (r *repositoryImpl) DeleteFoodNutrient(foodID, nutrientID string) (int64, error) {
+	result := r.db.Delete(&FoodNutrient{}, "food_id = ? AND nutrient_id = ?", foodID, nutrientID)
+	return result.RowsAffected, result.Error
+}
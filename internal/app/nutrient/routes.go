@@ -0,0 +1,27 @@
+package nutrient
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetAll)
+	r.Post("/", h.Create)
+	r.Get("/{id}", h.GetByID)
+	r.Put("/{id}", h.Update)
+	r.Delete("/{id}", h.Delete)
+
+	return r
+}
+
+// FoodRoutes serves the nutrient profile of a single food. It is meant to be
+// mounted under a router that provides the food ID as the "id" URL parameter.
+func (h *Handler) FoodRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetFoodProfile)
+	r.Put("/{nutrientId}", h.SetFoodNutrient)
+	r.Delete("/{nutrientId}", h.RemoveFoodNutrient)
+
+	return r
+}
@@ -0,0 +1,146 @@
+package nutrient
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrFoodNotFound     = errors.New("food not found")
+	ErrNutrientNotFound = errors.New("nutrient not found")
+	ErrNutrientInUse    = errors.New("nutrient is referenced by foods")
+)
+
+// ProfileListener is notified after the nutrient profile of a food has changed.
+type ProfileListener interface {
+	FoodUpdated(foodID string) error
+}
+
+type Service interface {
+	GetAll(category string, limit, offset int) ([]Nutrient, int64, error)
+	GetByID(id string) (*Nutrient, error)
+	Create(nutrient *Nutrient) error
+	Update(nutrient *Nutrient) error
+	Delete(id string) error
+
+	GetFoodProfile(foodID string) ([]FoodNutrient, error)
+	SetFoodNutrient(foodNutrient *FoodNutrient) error
+	RemoveFoodNutrient(foodID, nutrientID string) error
+	Subscribe(listener ProfileListener)
+}
+
+type serviceImpl struct {
+	repo      Repository
+	listeners []ProfileListener
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetAll(category string, limit, offset int) ([]Nutrient, int64, error) {
+	return s.repo.GetAll(category, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(id string) (*Nutrient, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *serviceImpl) Create(nutrient *Nutrient) error {
+	return s.repo.Create(nutrient)
+}
+
+func (s *serviceImpl) Update(nutrient *Nutrient) error {
+	existingNutrient, err := s.repo.GetByID(nutrient.ID)
+	if err != nil {
+		return err
+	}
+
+	nutrient.CreatedAt = existingNutrient.CreatedAt
+
+	return s.repo.Update(nutrient)
+}
+
+func (s *serviceImpl) Delete(id string) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountFoods(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrNutrientInUse
+	}
+
+	return s.repo.Delete(id)
+}
+
+func (s *serviceImpl) GetFoodProfile(foodID string) ([]FoodNutrient, error) {
+	if err := s.ensureFood(foodID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFoodProfile(foodID)
+}
+
+func (s *serviceImpl) SetFoodNutrient(foodNutrient *FoodNutrient) error {
+	if err := s.ensureFood(foodNutrient.FoodID); err != nil {
+		return err
+	}
+
+	nutrient, err := s.repo.GetByID(foodNutrient.NutrientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNutrientNotFound
+		}
+		return err
+	}
+
+	if err := s.repo.UpsertFoodNutrient(foodNutrient); err != nil {
+		return err
+	}
+
+	foodNutrient.Nutrient = nutrient
+	return s.notify(foodNutrient.FoodID)
+}
+
+func (s *serviceImpl) RemoveFoodNutrient(foodID, nutrientID string) error {
+	if err := s.ensureFood(foodID); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.DeleteFoodNutrient(foodID, nutrientID)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrNutrientNotFound
+	}
+	return s.notify(foodID)
+}
+
+func (s *serviceImpl) Subscribe(listener ProfileListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *serviceImpl) notify(foodID string) error {
+	for _, listener := range s.listeners {
+		if err := listener.FoodUpdated(foodID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *serviceImpl) ensureFood(foodID string) error {
+	exists, err := s.repo.FoodExists(foodID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrFoodNotFound
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package recipe
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/app/nutrient"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// NewHandlerFactory wires the recipe handler and subscribes it to food and
+// nutrient changes, so recipes are recomputed when an ingredient changes.
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger, foods *food.Service, nutrients nutrient.Service) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, foods)
+	validator := validator.New()
+	recipeLogger := logger.Named("RecipeHandler")
+
+	foods.Subscribe(service)
+	nutrients.Subscribe(service)
+
+	return NewHandler(service, validator, recipeLogger)
+}
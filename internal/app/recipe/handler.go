@@ -0,0 +1,239 @@
+package recipe
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	recipes, total, err := h.Service.GetAll(limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving recipes")
+		h.Logger.Error("Error retrieving recipes", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     recipes,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(recipes),
+	}
+
+	h.Logger.Info("Retrieved recipes", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(recipes)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var recipe Recipe
+	if err := json.NewDecoder(r.Body).Decode(&recipe); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(recipe); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(&recipe); err != nil {
+		h.writeSaveError(w, err, "", "Error creating recipe")
+		return
+	}
+
+	h.Logger.Info("Created new recipe", zap.String("id", recipe.ID), zap.String("name", recipe.Name))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(recipe); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing recipe ID")
+		h.Logger.Warn("Missing recipe ID in request")
+		return
+	}
+
+	recipe, err := h.Service.GetByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Recipe not found")
+			h.Logger.Warn("Recipe not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving recipe")
+		h.Logger.Error("Error retrieving recipe", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved recipe", zap.String("id", recipe.ID), zap.String("name", recipe.Name))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(recipe); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing recipe ID")
+		h.Logger.Warn("Missing recipe ID in request")
+		return
+	}
+
+	var updatedData Recipe
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(&updatedData); err != nil {
+		h.writeSaveError(w, err, id, "Error updating recipe")
+		return
+	}
+
+	h.Logger.Info("Updated recipe", zap.String("id", updatedData.ID), zap.String("name", updatedData.Name))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing recipe ID")
+		h.Logger.Warn("Missing recipe ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(id); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errors.WriteHTTPError(w, http.StatusNotFound, "Recipe not found")
+			h.Logger.Warn("Recipe not found", zap.String("id", id))
+		case ErrRecipeInUse:
+			errors.WriteHTTPError(w, http.StatusConflict, "Recipe is still in use")
+			h.Logger.Warn("Recipe is still in use", zap.String("id", id))
+		default:
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting recipe")
+			h.Logger.Error("Error deleting recipe", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.Logger.Info("Deleted recipe", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetNutrition(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing recipe ID")
+		h.Logger.Warn("Missing recipe ID in request")
+		return
+	}
+
+	nutrition, err := h.Service.GetNutrition(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Recipe not found")
+			h.Logger.Warn("Recipe not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating recipe nutrition")
+		h.Logger.Error("Error calculating recipe nutrition", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Calculated recipe nutrition", zap.String("id", id))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nutrition); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeSaveError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Recipe not found")
+		h.Logger.Warn("Recipe not found", zap.String("id", id))
+	case ErrIngredientNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Ingredient food not found")
+		h.Logger.Warn("Ingredient food not found", zap.String("id", id))
+	case food.ErrUnknownUnit:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Unknown ingredient unit")
+		h.Logger.Warn("Unknown ingredient unit", zap.String("id", id))
+	case food.ErrDensityRequired:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Ingredient food has no density, volume units cannot be used")
+		h.Logger.Warn("Volume unit without density", zap.String("id", id))
+	case ErrRecipeCycle:
+		errors.WriteHTTPError(w, http.StatusConflict, "Ingredient would make the recipe contain itself")
+		h.Logger.Warn("Recipe cycle", zap.String("id", id))
+	case ErrImplausibleYield:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Yield is too low for the ingredients")
+		h.Logger.Warn("Implausible recipe yield", zap.String("id", id))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
@@ -0,0 +1,52 @@
+package recipe
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+)
+
+// Recipe is a composite food. Every recipe owns a food.Food row holding its name
+// and computed per-100 g nutrition, so the recipe's FoodID can be used anywhere
+// a food is accepted.
+type Recipe struct {
+	ID           string       `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	FoodID       string       `json:"food_id" gorm:"type:uuid;not null"`
+	Name         string       `json:"name" gorm:"-" validate:"required,min=1,max=50"`
+	Servings     float64      `json:"servings" gorm:"not null" validate:"required,gt=0,lte=1000"`
+	YieldGrams   *float64     `json:"yield_grams" validate:"omitempty,gt=0,lte=100000"`
+	Instructions string       `json:"instructions" gorm:"not null;default:''" validate:"max=10000"`
+	Ingredients  []Ingredient `json:"ingredients" gorm:"foreignKey:RecipeID" validate:"required,min=1,max=100,dive"`
+	Food         *food.Food   `json:"food,omitempty" validate:"-"`
+	CreatedAt    time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Ingredient is an amount of a food in a recipe. Grams is resolved from Amount
+// and Unit when the recipe is saved.
+type Ingredient struct {
+	ID       string     `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	RecipeID string     `json:"recipe_id" gorm:"type:uuid;not null"`
+	FoodID   string     `json:"food_id" gorm:"type:uuid;not null" validate:"required,uuid"`
+	Amount   float64    `json:"amount" gorm:"not null" validate:"required,gt=0,lte=100000"`
+	Unit     string     `json:"unit" gorm:"not null" validate:"required,max=50"`
+	Grams    float64    `json:"grams" gorm:"not null"`
+	Position int        `json:"position" gorm:"not null"`
+	Food     *food.Food `json:"food,omitempty" validate:"-"`
+}
+
+func (Ingredient) TableName() string {
+	return "recipe_ingredients"
+}
+
+// Nutrition is the computed nutrition of a recipe.
+type Nutrition struct {
+	RecipeID     string         `json:"recipe_id"`
+	IngredientsG float64        `json:"ingredients_g"`
+	YieldG       float64        `json:"yield_g"`
+	Servings     float64        `json:"servings"`
+	ServingG     float64        `json:"serving_g"`
+	Total        food.Nutrition `json:"total"`
+	PerServing   food.Nutrition `json:"per_serving"`
+	Per100g      food.Nutrition `json:"per_100g"`
+}
@@ -0,0 +1,231 @@
+package recipe
+
+import (
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxDepth bounds the recursive ingredient queries.
+const maxDepth = 100
+
+// containsFoodSQL checks whether a food is used, directly or through nested
+// recipes, in the recipe owning the given food.
+const containsFoodSQL = `
+WITH RECURSIVE contained AS (
+    SELECT ri.food_id, 1 AS depth
+    FROM recipe_ingredients ri
+             JOIN recipes r ON r.id = ri.recipe_id
+    WHERE r.food_id = ?
+    UNION
+    SELECT ri.food_id, c.depth + 1
+    FROM contained c
+             JOIN recipes r ON r.food_id = c.food_id
+             JOIN recipe_ingredients ri ON ri.recipe_id = r.id
+    WHERE c.depth < ?
+)
+SELECT count(*) FROM contained WHERE food_id = ?`
+
+// servingPortionName is the portion kept in sync with the size of one serving.
+const servingPortionName = "serving"
+
+type Repository interface {
+	GetAll(limit, offset int) ([]Recipe, int64, error)
+	GetByID(id string) (*Recipe, error)
+	GetByFoodID(foodID string) (*Recipe, error)
+	GetUsingFood(foodID string) ([]Recipe, error)
+	Create(recipe *Recipe) error
+	Update(recipe *Recipe) error
+	Delete(recipe *Recipe) error
+	Contains(recipeFoodID, foodID string) (bool, error)
+	SaveNutrition(recipe *Recipe, per100g food.Nutrition, yieldGrams float64) error
+	// Transaction runs fn with a repository bound to a single database transaction.
+	Transaction(fn func(repo Repository) error) error
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(limit, offset int) ([]Recipe, int64, error) {
+	var recipes []Recipe
+	var total int64
+
+	if err := r.db.Model(&Recipe{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Joins("Food").
+		Preload("Ingredients", orderByPosition).
+		Order(`"Food".name`).
+		Limit(limit).Offset(offset).
+		Find(&recipes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range recipes {
+		recipes[i].Name = recipes[i].Food.Name
+	}
+	return recipes, total, nil
+}
+
+func (r *repositoryImpl) GetByID(id string) (*Recipe, error) {
+	return r.first("recipes.id = ?", id)
+}
+
+func (r *repositoryImpl) GetByFoodID(foodID string) (*Recipe, error) {
+	return r.first("recipes.food_id = ?", foodID)
+}
+
+func (r *repositoryImpl) first(query string, args ...interface{}) (*Recipe, error) {
+	var recipe Recipe
+	err := r.db.Joins("Food").
+		Preload("Ingredients", orderByPosition).
+		Preload("Ingredients.Food").
+		Where(query, args...).
+		First(&recipe).Error
+	if err != nil {
+		return nil, err
+	}
+
+	recipe.Name = recipe.Food.Name
+	return &recipe, nil
+}
+
+// GetUsingFood returns the recipes having the food as a direct ingredient.
+func (r *repositoryImpl) GetUsingFood(foodID string) ([]Recipe, error) {
+	var ids []string
+	err := r.db.Model(&Ingredient{}).Distinct("recipe_id").Where("food_id = ?", foodID).Pluck("recipe_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	recipes := make([]Recipe, 0, len(ids))
+	for _, id := range ids {
+		recipe, err := r.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, *recipe)
+	}
+	return recipes, nil
+}
+
+// Create stores the recipe together with the food representing it.
+func (r *repositoryImpl) Create(recipe *Recipe) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		recipeFood := &food.Food{Name: recipe.Name}
+		if err := tx.Omit(clause.Associations).Create(recipeFood).Error; err != nil {
+			return err
+		}
+
+		recipe.FoodID = recipeFood.ID
+		if err := tx.Omit(clause.Associations).Create(recipe).Error; err != nil {
+			return err
+		}
+
+		return createIngredients(tx, recipe)
+	})
+}
+
+// Update renames the recipe's food and replaces all ingredients.
+func (r *repositoryImpl) Update(recipe *Recipe) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&food.Food{}).Where("id = ?", recipe.FoodID).Update("name", recipe.Name).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Save(recipe).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&Ingredient{}, "recipe_id = ?", recipe.ID).Error; err != nil {
+			return err
+		}
+
+		return createIngredients(tx, recipe)
+	})
+}
+
+// Delete removes the recipe's food, which cascades to the recipe and its ingredients.
+func (r *repositoryImpl) Delete(recipe *Recipe) error {
+	return r.db.Delete(&food.Food{}, "id = ?", recipe.FoodID).Error
+}
+
+func (r *repositoryImpl) Contains(recipeFoodID, foodID string) (bool, error) {
+	var count int64
+	if err := r.db.Raw(containsFoodSQL, recipeFoodID, maxDepth, foodID).Scan(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SaveNutrition stores the computed nutrition on the recipe's food: macronutrients,
+// allergens and dietary flags taken from recipe.Food, the micronutrient profile
+// aggregated from the ingredients and a "serving" portion.
+func (r *repositoryImpl) SaveNutrition(recipe *Recipe, per100g food.Nutrition, yieldGrams float64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&food.Food{}).Where("id = ?", recipe.FoodID).Updates(map[string]interface{}{
+			"energy_kcal":        per100g.EnergyKcal,
+			"energy_kj":          per100g.EnergyKJ,
+			"protein_g":          per100g.ProteinG,
+			"fat_g":              per100g.FatG,
+			"saturated_fat_g":    per100g.SaturatedFatG,
+			"carbohydrate_g":     per100g.CarbohydrateG,
+			"sugar_g":            per100g.SugarG,
+			"fiber_g":            per100g.FiberG,
+			"sodium_mg":          per100g.SodiumMg,
+			"allergens":          recipe.Food.Allergens,
+			"allergens_verified": recipe.Food.AllergensVerified,
+			"diets":              recipe.Food.Diets,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`DELETE FROM food_nutrients WHERE food_id = ?`, recipe.FoodID).Error; err != nil {
+			return err
+		}
+
+		err = tx.Exec(`
+INSERT INTO food_nutrients (food_id, nutrient_id, amount)
+SELECT ?, fn.nutrient_id, SUM(fn.amount * ri.grams / 100) * 100 / ?
+FROM recipe_ingredients ri
+         JOIN food_nutrients fn ON fn.food_id = ri.food_id
+WHERE ri.recipe_id = ?
+GROUP BY fn.nutrient_id`, recipe.FoodID, yieldGrams, recipe.ID).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+INSERT INTO food_portions (food_id, name, grams)
+VALUES (?, ?, ?)
+ON CONFLICT (food_id, lower(name)) DO UPDATE SET grams = EXCLUDED.grams, updated_at = CURRENT_TIMESTAMP`,
+			recipe.FoodID, servingPortionName, yieldGrams/recipe.Servings).Error
+	})
+}
+
+func (r *repositoryImpl) Transaction(fn func(repo Repository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&repositoryImpl{db: tx})
+	})
+}
+
+func createIngredients(tx *gorm.DB, recipe *Recipe) error {
+	for i := range recipe.Ingredients {
+		recipe.Ingredients[i].ID = ""
+		recipe.Ingredients[i].RecipeID = recipe.ID
+		recipe.Ingredients[i].Position = i
+	}
+	return tx.Omit(clause.Associations).Create(&recipe.Ingredients).Error
+}
+
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
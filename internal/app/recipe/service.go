@@ -0,0 +1,284 @@
+package recipe
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIngredientNotFound = errors.New("ingredient food not found")
+	ErrRecipeCycle        = errors.New("recipe cannot contain itself")
+	ErrRecipeInUse        = errors.New("recipe is still referenced")
+	ErrImplausibleYield   = errors.New("yield is too low for the ingredients")
+)
+
+type Service interface {
+	GetAll(limit, offset int) ([]Recipe, int64, error)
+	GetByID(id string) (*Recipe, error)
+	Create(recipe *Recipe) error
+	Update(recipe *Recipe) error
+	Delete(id string) error
+	GetNutrition(id string) (*Nutrition, error)
+
+	// FoodUpdated refreshes the recipes affected by a change of a food.
+	FoodUpdated(foodID string) error
+}
+
+type serviceImpl struct {
+	repo  Repository
+	foods *food.Service
+}
+
+func NewService(repo Repository, foods *food.Service) Service {
+	return &serviceImpl{repo: repo, foods: foods}
+}
+
+func (s *serviceImpl) GetAll(limit, offset int) ([]Recipe, int64, error) {
+	return s.repo.GetAll(limit, offset)
+}
+
+func (s *serviceImpl) GetByID(id string) (*Recipe, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *serviceImpl) Create(recipe *Recipe) error {
+	if err := s.resolveIngredients(recipe); err != nil {
+		return err
+	}
+
+	return s.repo.Transaction(func(repo Repository) error {
+		if err := repo.Create(recipe); err != nil {
+			return err
+		}
+		return s.refresh(repo, recipe.ID, recipe)
+	})
+}
+
+func (s *serviceImpl) Update(recipe *Recipe) error {
+	existingRecipe, err := s.repo.GetByID(recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	recipe.FoodID = existingRecipe.FoodID
+	recipe.CreatedAt = existingRecipe.CreatedAt
+
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.FoodID == recipe.FoodID {
+			return ErrRecipeCycle
+		}
+		cycle, err := s.repo.Contains(ingredient.FoodID, recipe.FoodID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrRecipeCycle
+		}
+	}
+
+	if err := s.resolveIngredients(recipe); err != nil {
+		return err
+	}
+
+	return s.repo.Transaction(func(repo Repository) error {
+		if err := repo.Update(recipe); err != nil {
+			return err
+		}
+		if err := s.refresh(repo, recipe.ID, recipe); err != nil {
+			return err
+		}
+		return s.propagate(repo, recipe.FoodID, map[string]bool{recipe.ID: true})
+	})
+}
+
+func (s *serviceImpl) Delete(id string) error {
+	recipe, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(recipe); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrRecipeInUse
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *serviceImpl) GetNutrition(id string) (*Nutrition, error) {
+	recipe, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return computeNutrition(recipe), nil
+}
+
+func (s *serviceImpl) FoodUpdated(foodID string) error {
+	return s.repo.Transaction(func(repo Repository) error {
+		visited := map[string]bool{}
+
+		// A recipe's own food was edited directly: restore its computed values.
+		recipe, err := repo.GetByFoodID(foodID)
+		if err == nil {
+			if err := s.save(repo, recipe); err != nil {
+				return err
+			}
+			visited[recipe.ID] = true
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		return s.propagate(repo, foodID, visited)
+	})
+}
+
+// propagate recomputes every recipe using the food, then the recipes using those recipes.
+func (s *serviceImpl) propagate(repo Repository, foodID string, visited map[string]bool) error {
+	recipes, err := repo.GetUsingFood(foodID)
+	if err != nil {
+		return err
+	}
+
+	for i := range recipes {
+		recipe := &recipes[i]
+		if visited[recipe.ID] {
+			continue
+		}
+		visited[recipe.ID] = true
+
+		if err := s.save(repo, recipe); err != nil {
+			return err
+		}
+		if err := s.propagate(repo, recipe.FoodID, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refresh reloads a freshly written recipe, stores its computed nutrition and copies it into out.
+func (s *serviceImpl) refresh(repo Repository, id string, out *Recipe) error {
+	recipe, err := repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.save(repo, recipe); err != nil {
+		return err
+	}
+
+	*out = *recipe
+	return nil
+}
+
+func (s *serviceImpl) save(repo Repository, recipe *Recipe) error {
+	computeFlags(recipe)
+	nutrition := computeNutrition(recipe)
+	if err := repo.SaveNutrition(recipe, nutrition.Per100g, nutrition.YieldG); err != nil {
+		if errors.Is(err, gorm.ErrCheckConstraintViolated) {
+			return ErrImplausibleYield
+		}
+		return err
+	}
+
+	recipe.Food.Nutrition = nutrition.Per100g
+	return nil
+}
+
+// resolveIngredients converts every ingredient amount into grams.
+func (s *serviceImpl) resolveIngredients(recipe *Recipe) error {
+	for i := range recipe.Ingredients {
+		ingredient := &recipe.Ingredients[i]
+		grams, err := s.foods.ToGrams(ingredient.FoodID, ingredient.Amount, ingredient.Unit)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrIngredientNotFound
+			}
+			return err
+		}
+		ingredient.Grams = grams
+	}
+	return nil
+}
+
+// computeFlags sets the allergens of the recipe's food to those of any ingredient and its
+// dietary flags to those shared by every ingredient. The allergens are verified only when
+// they are verified for every ingredient.
+func computeFlags(recipe *Recipe) {
+	allergens := map[string]bool{}
+	verified := true
+	var diets map[string]bool
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.Food == nil {
+			verified = false
+			diets = map[string]bool{}
+			continue
+		}
+
+		for _, allergen := range ingredient.Food.Allergens {
+			allergens[allergen] = true
+		}
+		verified = verified && ingredient.Food.AllergensVerified
+
+		ingredientDiets := make(map[string]bool, len(ingredient.Food.Diets))
+		for _, diet := range ingredient.Food.Diets {
+			ingredientDiets[diet] = true
+		}
+		if diets == nil {
+			diets = ingredientDiets
+			continue
+		}
+		for diet := range diets {
+			if !ingredientDiets[diet] {
+				delete(diets, diet)
+			}
+		}
+	}
+
+	recipe.Food.Allergens = sortedKeys(allergens)
+	recipe.Food.AllergensVerified = verified
+	recipe.Food.Diets = sortedKeys(diets)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func computeNutrition(recipe *Recipe) *Nutrition {
+	var total food.Nutrition
+	ingredientsGrams := 0.0
+	for _, ingredient := range recipe.Ingredients {
+		ingredientsGrams += ingredient.Grams
+		if ingredient.Food != nil {
+			total = total.Add(ingredient.Food.Nutrition.Scale(ingredient.Grams / 100))
+		}
+	}
+
+	yieldGrams := ingredientsGrams
+	if recipe.YieldGrams != nil {
+		yieldGrams = *recipe.YieldGrams
+	}
+
+	nutrition := &Nutrition{
+		RecipeID:     recipe.ID,
+		IngredientsG: ingredientsGrams,
+		YieldG:       yieldGrams,
+		Servings:     recipe.Servings,
+		ServingG:     yieldGrams / recipe.Servings,
+		Total:        total.Round(2),
+		PerServing:   total.Scale(1 / recipe.Servings).Round(2),
+	}
+	if yieldGrams > 0 {
+		nutrition.Per100g = total.Scale(100 / yieldGrams).Round(2)
+	}
+	return nutrition
+}
@@ -0,0 +1,95 @@
+package recipe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/food"
+)
+
+func TestComputeNutrition(t *testing.T) {
+	cooked := 500.0
+	ingredients := []Ingredient{
+		{FoodID: "rice", Grams: 200, Food: &food.Food{Nutrition: food.Nutrition{EnergyKcal: 130, ProteinG: 2.7, CarbohydrateG: 28}}},
+		{FoodID: "oil", Grams: 10, Food: &food.Food{Nutrition: food.Nutrition{EnergyKcal: 884, FatG: 100}}},
+	}
+	perServing := food.Nutrition{EnergyKcal: 174.2, ProteinG: 2.7, FatG: 5, CarbohydrateG: 28}
+
+	tests := []struct {
+		name        string
+		yield       *float64
+		wantYield   float64
+		wantServing float64
+		wantPer100g food.Nutrition
+	}{
+		{"raw weight", nil, 210, 105, food.Nutrition{EnergyKcal: 165.9, ProteinG: 2.57, FatG: 4.76, CarbohydrateG: 26.67}},
+		{"cooked yield", &cooked, 500, 250, food.Nutrition{EnergyKcal: 69.68, ProteinG: 1.08, FatG: 2, CarbohydrateG: 11.2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeNutrition(&Recipe{ID: "recipe-1", Servings: 2, YieldGrams: tt.yield, Ingredients: ingredients})
+
+			if got.IngredientsG != 210 || got.YieldG != tt.wantYield || got.ServingG != tt.wantServing {
+				t.Errorf("computeNutrition() grams = %v/%v/%v, want 210/%v/%v",
+					got.IngredientsG, got.YieldG, got.ServingG, tt.wantYield, tt.wantServing)
+			}
+			if got.PerServing != perServing {
+				t.Errorf("computeNutrition() per serving = %+v, want %+v", got.PerServing, perServing)
+			}
+			if got.Per100g != tt.wantPer100g {
+				t.Errorf("computeNutrition() per 100 g = %+v, want %+v", got.Per100g, tt.wantPer100g)
+			}
+		})
+	}
+}
+
+// fakeRepository serves one recipe and reports which foods contain the recipe's food.
+type fakeRepository struct {
+	Repository
+	recipe       Recipe
+	containing   map[string]bool
+	transactions int
+}
+
+func (r *fakeRepository) GetByID(id string) (*Recipe, error) {
+	recipe := r.recipe
+	return &recipe, nil
+}
+
+func (r *fakeRepository) Contains(recipeFoodID, foodID string) (bool, error) {
+	return foodID == r.recipe.FoodID && r.containing[recipeFoodID], nil
+}
+
+func (r *fakeRepository) Transaction(fn func(repo Repository) error) error {
+	r.transactions++
+	return nil
+}
+
+func TestUpdateRejectsCycles(t *testing.T) {
+	tests := []struct {
+		name   string
+		foodID string
+	}{
+		{"recipe itself", "soup-food"},
+		{"recipe containing it", "stew-food"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{
+				recipe:     Recipe{ID: "soup", FoodID: "soup-food"},
+				containing: map[string]bool{"stew-food": true},
+			}
+			service := NewService(repo, nil)
+
+			err := service.Update(&Recipe{ID: "soup", Ingredients: []Ingredient{{FoodID: tt.foodID}}})
+			if !errors.Is(err, ErrRecipeCycle) {
+				t.Errorf("Update() = %v, want %v", err, ErrRecipeCycle)
+			}
+			if repo.transactions != 0 {
+				t.Errorf("Update() saved the recipe %d times, want 0", repo.transactions)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package session
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/user"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger, users user.Service, issuer *auth.Issuer) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, issuer)
+	validator := validator.New()
+	sessionLogger := logger.Named("SessionHandler")
+
+	return NewHandler(service, users, validator, sessionLogger)
+}
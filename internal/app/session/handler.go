@@ -0,0 +1,181 @@
+package session
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/app/user"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net"
+	"net/http"
+)
+
+type Handler struct {
+	Service   Service
+	Users     user.Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, users user.Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Users:     users,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	var credentials user.Credentials
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(credentials); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	account, err := h.Users.Login(&credentials)
+	if err != nil {
+		h.writeSessionError(w, err, "", "Error logging in")
+		return
+	}
+
+	tokens, err := h.Service.Start(account.ID, clientOf(r))
+	if err != nil {
+		h.writeSessionError(w, err, "", "Error starting session")
+		return
+	}
+
+	h.Logger.Info("User logged in", zap.String("user_id", account.ID), zap.String("session_id", tokens.SessionID))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(Login{Tokens: *tokens, User: account}); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var request RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(request); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	tokens, err := h.Service.Refresh(request.RefreshToken, clientOf(r))
+	if err != nil {
+		h.writeSessionError(w, err, "", "Error refreshing session")
+		return
+	}
+
+	h.Logger.Info("Refreshed session", zap.String("session_id", tokens.SessionID))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.RequirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Revoke(principal, principal.SessionID, RevokeReasonLogout); err != nil {
+		h.writeSessionError(w, err, principal.SessionID, "Error logging out")
+		return
+	}
+
+	h.Logger.Info("User logged out", zap.String("user_id", principal.UserID), zap.String("session_id", principal.SessionID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.RequirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.Service.GetSessions(principal)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving sessions")
+		h.Logger.Error("Error retrieving sessions", zap.String("user_id", principal.UserID), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved sessions", zap.String("user_id", principal.UserID), zap.Int("returned", len(sessions)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sessions); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.RequirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing session ID")
+		h.Logger.Warn("Missing session ID in request")
+		return
+	}
+
+	if err := h.Service.Revoke(principal, id, RevokeReasonUser); err != nil {
+		h.writeSessionError(w, err, id, "Error revoking session")
+		return
+	}
+
+	h.Logger.Info("Revoked session", zap.String("user_id", principal.UserID), zap.String("session_id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeSessionError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Session not found")
+		h.Logger.Warn("Session not found", zap.String("id", id))
+	case user.ErrInvalidCredentials:
+		errors.WriteHTTPError(w, http.StatusUnauthorized, "Invalid email or password")
+		h.Logger.Warn("Invalid login credentials")
+	case user.ErrAccountLocked:
+		errors.WriteHTTPError(w, http.StatusLocked, "Account is locked after too many failed logins, try again later")
+		h.Logger.Warn("Login to a locked account")
+	case ErrInvalidRefreshToken:
+		errors.WriteHTTPError(w, http.StatusUnauthorized, "Invalid or expired refresh token")
+		h.Logger.Warn("Invalid refresh token")
+	case ErrRefreshTokenReused:
+		errors.WriteHTTPError(w, http.StatusUnauthorized, "Refresh token was already used, the session has been revoked")
+		h.Logger.Warn("Refresh token reused, session revoked")
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
+
+// clientOf describes the device a request comes from.
+func clientOf(r *http.Request) Client {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return Client{UserAgent: r.UserAgent(), IPAddress: ip}
+}
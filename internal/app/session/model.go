@@ -0,0 +1,68 @@
+package session
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/app/user"
+)
+
+// Reasons a session was revoked.
+const (
+	RevokeReasonLogout = "logout"
+	RevokeReasonUser   = "revoked"
+	RevokeReasonReuse  = "reuse_detected"
+)
+
+// Session is a login on one device: the family of refresh tokens rotated from
+// the one issued at login. Revoking it invalidates every token in the family.
+type Session struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID       string     `json:"-" gorm:"type:uuid;not null"`
+	UserAgent    string     `json:"user_agent" gorm:"not null;default:''"`
+	IPAddress    string     `json:"ip_address" gorm:"not null;default:''"`
+	Current      bool       `json:"current" gorm:"-"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	LastUsedAt   time.Time  `json:"last_used_at" gorm:"not null"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt    *time.Time `json:"-"`
+	RevokeReason string     `json:"-" gorm:"not null;default:''"`
+}
+
+// RefreshToken is a single-use refresh token. Only its SHA-256 hash is stored;
+// UsedAt is set when it is rotated, after which presenting it again is reuse.
+type RefreshToken struct {
+	ID        string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	SessionID string    `gorm:"type:uuid;not null"`
+	TokenHash string    `gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// Client describes the device a session was started or refreshed from.
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Tokens are issued at login and on every refresh. The refresh token replaces
+// the one presented, which must not be used again.
+type Tokens struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	SessionID        string    `json:"session_id"`
+}
+
+// Login is the response to a successful login.
+type Login struct {
+	Tokens
+	User *user.User `json:"user"`
+}
+
+// RefreshRequest is the body of a refresh request.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=200"`
+}
@@ -0,0 +1,92 @@
+package session
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateSession(session *Session, token *RefreshToken) error
+	GetSession(id string) (*Session, error)
+	GetActiveSessions(userID string, now time.Time) ([]Session, error)
+	RevokeSession(id, reason string, at time.Time) error
+
+	GetTokenByHash(hash string) (*RefreshToken, error)
+	// Rotate marks the token used and stores its successor in one transaction.
+	// It returns errTokenUsed when the token was used concurrently.
+	Rotate(used *RefreshToken, next *RefreshToken, at time.Time) error
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) CreateSession(session *Session, token *RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		token.SessionID = session.ID
+		return tx.Create(token).Error
+	})
+}
+
+func (r *repositoryImpl) GetSession(id string) (*Session, error) {
+	var session Session
+	if err := r.db.First(&session, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetActiveSessions returns the user's sessions that are neither revoked nor
+// expired, most recently used first.
+func (r *repositoryImpl) GetActiveSessions(userID string, now time.Time) ([]Session, error) {
+	var sessions []Session
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeSession revokes a session unless it is revoked already, keeping the
+// first reason.
+func (r *repositoryImpl) RevokeSession(id, reason string, at time.Time) error {
+	return r.db.Model(&Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": at, "revoke_reason": reason}).Error
+}
+
+func (r *repositoryImpl) GetTokenByHash(hash string) (*RefreshToken, error) {
+	var token RefreshToken
+	if err := r.db.First(&token, "token_hash = ?", hash).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *repositoryImpl) Rotate(used *RefreshToken, next *RefreshToken, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", used.ID).
+			Update("used_at", at)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTokenUsed
+		}
+
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Session{}).Where("id = ?", used.SessionID).
+			Updates(map[string]interface{}{"last_used_at": at, "expires_at": next.ExpiresAt}).Error
+	})
+}
@@ -0,0 +1,24 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Routes serves login and refresh publicly and the rest behind authenticate.
+func (h *Handler) Routes(authenticate func(http.Handler) http.Handler) chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/login", h.Login)
+	r.Post("/refresh", h.Refresh)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Post("/logout", h.Logout)
+		r.Get("/sessions", h.GetSessions)
+		r.Delete("/sessions/{id}", h.RevokeSession)
+	})
+
+	return r
+}
@@ -0,0 +1,172 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenTTL is how long a refresh token is accepted. Every refresh
+// issues a new one, so a session lasts while it is used at least this often.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+
+	errTokenUsed = errors.New("refresh token already used")
+)
+
+type Service interface {
+	// Start opens a session for a user who has just logged in.
+	Start(userID string, client Client) (*Tokens, error)
+	// Refresh rotates a refresh token. Presenting a token that was already
+	// rotated revokes its whole session, as the token has probably leaked.
+	Refresh(refreshToken string, client Client) (*Tokens, error)
+	GetSessions(principal *auth.Principal) ([]Session, error)
+	// Revoke ends one of the principal's sessions.
+	Revoke(principal *auth.Principal, sessionID, reason string) error
+}
+
+type serviceImpl struct {
+	repo   Repository
+	issuer *auth.Issuer
+}
+
+func NewService(repo Repository, issuer *auth.Issuer) Service {
+	return &serviceImpl{repo: repo, issuer: issuer}
+}
+
+func (s *serviceImpl) Start(userID string, client Client) (*Tokens, error) {
+	now := time.Now()
+	token, refreshToken, err := newRefreshToken(now)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{
+		UserID:     userID,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		LastUsedAt: now,
+		ExpiresAt:  token.ExpiresAt,
+	}
+	if err := s.repo.CreateSession(session, token); err != nil {
+		return nil, err
+	}
+
+	return s.issue(session, token, refreshToken, now)
+}
+
+func (s *serviceImpl) Refresh(refreshToken string, client Client) (*Tokens, error) {
+	now := time.Now()
+	token, err := s.repo.GetTokenByHash(hashToken(refreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.repo.GetSession(token.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.RevokedAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if token.UsedAt != nil {
+		return nil, s.revokeReused(session.ID, now)
+	}
+	if !now.Before(token.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	next, nextToken, err := newRefreshToken(now)
+	if err != nil {
+		return nil, err
+	}
+	next.SessionID = session.ID
+	if err := s.repo.Rotate(token, next, now); err != nil {
+		if errors.Is(err, errTokenUsed) {
+			return nil, s.revokeReused(session.ID, now)
+		}
+		return nil, err
+	}
+
+	session.LastUsedAt = now
+	session.ExpiresAt = next.ExpiresAt
+	return s.issue(session, next, nextToken, now)
+}
+
+func (s *serviceImpl) GetSessions(principal *auth.Principal) ([]Session, error) {
+	sessions, err := s.repo.GetActiveSessions(principal.UserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == principal.SessionID
+	}
+	return sessions, nil
+}
+
+func (s *serviceImpl) Revoke(principal *auth.Principal, sessionID, reason string) error {
+	session, err := s.repo.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != principal.UserID || session.RevokedAt != nil {
+		return gorm.ErrRecordNotFound
+	}
+	return s.repo.RevokeSession(session.ID, reason, time.Now())
+}
+
+func (s *serviceImpl) revokeReused(sessionID string, now time.Time) error {
+	if err := s.repo.RevokeSession(sessionID, RevokeReasonReuse, now); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (s *serviceImpl) issue(session *Session, token *RefreshToken, refreshToken string, now time.Time) (*Tokens, error) {
+	principal := auth.Principal{UserID: session.UserID, SessionID: session.ID}
+	accessToken, expiresAt, err := s.issuer.Issue(principal, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: token.ExpiresAt,
+		SessionID:        session.ID,
+	}, nil
+}
+
+// newRefreshToken generates a random refresh token, returning the record to
+// store and the token to hand out.
+func newRefreshToken(now time.Time) (*RefreshToken, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(secret)
+
+	return &RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: now.Add(RefreshTokenTTL),
+	}, refreshToken, nil
+}
+
+func hashToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,228 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"gorm.io/gorm"
+)
+
+// fakeRepository keeps sessions and refresh tokens in memory.
+type fakeRepository struct {
+	sessions map[string]*Session
+	tokens   map[string]*RefreshToken
+	// usedConcurrently makes the next Rotate find its token used already.
+	usedConcurrently bool
+	ids              int
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{sessions: map[string]*Session{}, tokens: map[string]*RefreshToken{}}
+}
+
+func (r *fakeRepository) nextID() string {
+	r.ids++
+	return fmt.Sprintf("id-%d", r.ids)
+}
+
+func (r *fakeRepository) CreateSession(session *Session, token *RefreshToken) error {
+	session.ID = r.nextID()
+	r.sessions[session.ID] = session
+	token.ID = r.nextID()
+	token.SessionID = session.ID
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (r *fakeRepository) GetSession(id string) (*Session, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *fakeRepository) GetActiveSessions(userID string, now time.Time) ([]Session, error) {
+	var sessions []Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil && now.Before(session.ExpiresAt) {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *fakeRepository) RevokeSession(id, reason string, at time.Time) error {
+	session := r.sessions[id]
+	session.RevokedAt = &at
+	session.RevokeReason = reason
+	return nil
+}
+
+func (r *fakeRepository) GetTokenByHash(hash string) (*RefreshToken, error) {
+	token, ok := r.tokens[hash]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (r *fakeRepository) Rotate(used *RefreshToken, next *RefreshToken, at time.Time) error {
+	stored := r.tokens[used.TokenHash]
+	if r.usedConcurrently {
+		stored.UsedAt = &at
+	}
+	if stored.UsedAt != nil {
+		return errTokenUsed
+	}
+	stored.UsedAt = &at
+	next.ID = r.nextID()
+	r.tokens[next.TokenHash] = next
+	return nil
+}
+
+const testSecret = "test-secret-of-at-least-32-bytes!"
+
+func newTestService() (*fakeRepository, *auth.Issuer, Service) {
+	repo := newFakeRepository()
+	issuer := auth.NewIssuer(testSecret, auth.AccessTokenTTL)
+	return repo, issuer, NewService(repo, issuer)
+}
+
+func TestStart(t *testing.T) {
+	repo, issuer, service := newTestService()
+
+	tokens, err := service.Start("user-1", Client{UserAgent: "curl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	principal, err := issuer.Parse(tokens.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.UserID != "user-1" || principal.SessionID != tokens.SessionID {
+		t.Errorf("access token principal = %+v, want user-1 in session %s", principal, tokens.SessionID)
+	}
+	if _, stored := repo.tokens[tokens.RefreshToken]; stored {
+		t.Error("refresh token stored in plain text")
+	}
+	if _, stored := repo.tokens[hashToken(tokens.RefreshToken)]; !stored {
+		t.Error("refresh token hash not stored")
+	}
+}
+
+func TestRefreshRotates(t *testing.T) {
+	repo, _, service := newTestService()
+	login, err := service.Start("user-1", Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := service.Refresh(login.RefreshToken, Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Error("Refresh() returned the same refresh token")
+	}
+	if refreshed.SessionID != login.SessionID {
+		t.Errorf("Refresh() moved to session %s, want %s", refreshed.SessionID, login.SessionID)
+	}
+	if repo.tokens[hashToken(login.RefreshToken)].UsedAt == nil {
+		t.Error("rotated refresh token not marked used")
+	}
+
+	if _, err := service.Refresh(refreshed.RefreshToken, Client{}); err != nil {
+		t.Errorf("Refresh() with the new token error = %v", err)
+	}
+}
+
+func TestRefreshReuseRevokesSession(t *testing.T) {
+	repo, _, service := newTestService()
+	login, err := service.Start("user-1", Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := service.Refresh(login.RefreshToken, Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Refresh(login.RefreshToken, Client{}); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("Refresh() with a used token error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	session := repo.sessions[login.SessionID]
+	if session.RevokedAt == nil || session.RevokeReason != RevokeReasonReuse {
+		t.Errorf("session revoked at %v for %q, want revoked for %q", session.RevokedAt, session.RevokeReason, RevokeReasonReuse)
+	}
+
+	if _, err := service.Refresh(refreshed.RefreshToken, Client{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() with the latest token of a revoked session error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshConcurrentUseRevokesSession(t *testing.T) {
+	repo, _, service := newTestService()
+	login, err := service.Start("user-1", Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo.usedConcurrently = true
+	if _, err := service.Refresh(login.RefreshToken, Client{}); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("Refresh() racing another refresh error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	if repo.sessions[login.SessionID].RevokedAt == nil {
+		t.Error("session not revoked")
+	}
+}
+
+func TestRefreshRejects(t *testing.T) {
+	repo, _, service := newTestService()
+	login, err := service.Start("user-1", Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Refresh("unknown", Client{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() with an unknown token error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+
+	repo.tokens[hashToken(login.RefreshToken)].ExpiresAt = time.Now().Add(-time.Minute)
+	if _, err := service.Refresh(login.RefreshToken, Client{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() with an expired token error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	if repo.sessions[login.SessionID].RevokedAt != nil {
+		t.Error("expired token revoked the session")
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	_, _, service := newTestService()
+	login, err := service.Start("user-1", Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := &auth.Principal{UserID: "user-2"}
+	if err := service.Revoke(other, login.SessionID, RevokeReasonUser); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Revoke() of another user's session error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+
+	owner := &auth.Principal{UserID: "user-1", SessionID: login.SessionID}
+	if err := service.Revoke(owner, login.SessionID, RevokeReasonLogout); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Refresh(login.RefreshToken, Client{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() after logout error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	if err := service.Revoke(owner, login.SessionID, RevokeReasonLogout); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Revoke() twice error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+}
@@ -0,0 +1,266 @@
+package sleep
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	sessions, total, err := h.Service.GetSessions(userID, period, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving sleep sessions")
+		h.Logger.Error("Error retrieving sleep sessions", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     sessions,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(sessions),
+	}
+
+	h.Logger.Info("Retrieved sleep sessions", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(sessions)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var session Session
+	if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(session); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.CreateSession(userID, &session); err != nil {
+		h.writeSessionError(w, err, "", "Error creating sleep session")
+		return
+	}
+
+	h.Logger.Info("Created sleep session", zap.String("id", session.ID), zap.Float64("total_sleep_min", session.TotalSleepMin))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetSessionByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing sleep session ID")
+		h.Logger.Warn("Missing sleep session ID in request")
+		return
+	}
+
+	session, err := h.Service.GetSessionByID(userID, id)
+	if err != nil {
+		h.writeSessionError(w, err, id, "Error retrieving sleep session")
+		return
+	}
+
+	h.Logger.Info("Retrieved sleep session", zap.String("id", session.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing sleep session ID")
+		h.Logger.Warn("Missing sleep session ID in request")
+		return
+	}
+
+	var updatedData Session
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.UpdateSession(userID, &updatedData); err != nil {
+		h.writeSessionError(w, err, id, "Error updating sleep session")
+		return
+	}
+
+	h.Logger.Info("Updated sleep session", zap.String("id", updatedData.ID), zap.Float64("total_sleep_min", updatedData.TotalSleepMin))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing sleep session ID")
+		h.Logger.Warn("Missing sleep session ID in request")
+		return
+	}
+
+	if err := h.Service.DeleteSession(userID, id); err != nil {
+		h.writeSessionError(w, err, id, "Error deleting sleep session")
+		return
+	}
+
+	h.Logger.Info("Deleted sleep session", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	date := chi.URLParam(r, "date")
+	parsed, err := db.ParseDate(date)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+		h.Logger.Warn("Invalid date", zap.String("date", date))
+		return
+	}
+
+	summary, err := h.Service.GetSummary(userID, parsed.In(location))
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error calculating sleep summary")
+		h.Logger.Error("Error calculating sleep summary", zap.String("date", date), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Calculated sleep summary", zap.String("date", date), zap.Int("nights", summary.Averages.Nights))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeSessionError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "Sleep session not found")
+		h.Logger.Warn("Sleep session not found", zap.String("id", id))
+	case ErrSessionTooLong:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Sleep session is longer than 24 hours")
+		h.Logger.Warn("Sleep session too long", zap.String("id", id))
+	case ErrStageOutsideSession:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Sleep stages must lie within the session")
+		h.Logger.Warn("Sleep stage outside the session", zap.String("id", id))
+	case ErrStagesOverlap:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Sleep stages overlap")
+		h.Logger.Warn("Sleep stages overlap", zap.String("id", id))
+	case ErrImplausibleSleep:
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Latency and awake time exceed the time in bed")
+		h.Logger.Warn("Implausible sleep session", zap.String("id", id))
+	case ErrSessionOverlap:
+		errors.WriteHTTPError(w, http.StatusConflict, "Sleep session overlaps another session")
+		h.Logger.Warn("Overlapping sleep session", zap.String("id", id))
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
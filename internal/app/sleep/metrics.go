@@ -0,0 +1,210 @@
+package sleep
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// AverageDays is the number of nights a summary averages over.
+const AverageDays = 7
+
+// maxSessionMinutes bounds a session to one day in bed.
+const maxSessionMinutes = 24 * 60
+
+// minutesPerDay is the period of the clock that bedtimes and wake times wrap around.
+const minutesPerDay = 24 * 60
+
+// dateLayout is the format of plain dates.
+const dateLayout = "2006-01-02"
+
+// clockLayout is the format of averaged clock times.
+const clockLayout = "15:04"
+
+var (
+	ErrSessionTooLong      = errors.New("sleep session longer than 24 hours")
+	ErrStageOutsideSession = errors.New("sleep stage outside the session")
+	ErrStagesOverlap       = errors.New("sleep stages overlap")
+	ErrImplausibleSleep    = errors.New("latency and awake time exceed the time in bed")
+)
+
+// measure sets the computed metrics of a session. With stages, the latency runs
+// until the first sleep stage and every other minute in bed that is not asleep
+// counts as awake.
+func measure(session *Session) error {
+	timeInBed := session.EndedAt.Sub(session.StartedAt).Minutes()
+	if timeInBed > maxSessionMinutes {
+		return ErrSessionTooLong
+	}
+
+	asleep := timeInBed - session.LatencyMin - session.AwakeMin
+	if len(session.Stages) > 0 {
+		sort.Slice(session.Stages, func(i, j int) bool {
+			return session.Stages[i].StartedAt.Before(session.Stages[j].StartedAt)
+		})
+
+		var onset *time.Time
+		asleep = 0
+		for i, stage := range session.Stages {
+			if stage.StartedAt.Before(session.StartedAt) || stage.EndedAt.After(session.EndedAt) {
+				return ErrStageOutsideSession
+			}
+			if i > 0 && stage.StartedAt.Before(session.Stages[i-1].EndedAt) {
+				return ErrStagesOverlap
+			}
+			if stage.Type == StageAwake {
+				continue
+			}
+			if onset == nil {
+				onset = &session.Stages[i].StartedAt
+			}
+			asleep += stage.EndedAt.Sub(stage.StartedAt).Minutes()
+		}
+
+		session.LatencyMin = timeInBed
+		if onset != nil {
+			session.LatencyMin = onset.Sub(session.StartedAt).Minutes()
+		}
+		session.AwakeMin = reporting.Round(timeInBed-session.LatencyMin-asleep, 1)
+		session.LatencyMin = reporting.Round(session.LatencyMin, 1)
+	}
+
+	if asleep < 0 {
+		return ErrImplausibleSleep
+	}
+
+	session.TimeInBedMin = reporting.Round(timeInBed, 1)
+	session.TotalSleepMin = reporting.Round(asleep, 1)
+	session.Efficiency = reporting.Round(asleep/timeInBed*100, 1)
+	return nil
+}
+
+// nights groups sessions by the local day they end on, in chronological order.
+func nights(sessions []Session, location *time.Location) []Night {
+	var result []Night
+	var longest float64
+	var ratings []int
+
+	for _, session := range sessions {
+		date := session.EndedAt.In(location).Format(dateLayout)
+		if len(result) == 0 || result[len(result)-1].Date != date {
+			if len(result) > 0 {
+				finishNight(&result[len(result)-1], ratings)
+			}
+			result = append(result, Night{Date: date})
+			longest, ratings = 0, nil
+		}
+
+		night := &result[len(result)-1]
+		night.Sessions++
+		night.TimeInBedMin += session.TimeInBedMin
+		night.TotalSleepMin += session.TotalSleepMin
+		if session.Quality != nil {
+			ratings = append(ratings, *session.Quality)
+		}
+		if session.TimeInBedMin > longest {
+			longest = session.TimeInBedMin
+			night.Bedtime = session.StartedAt.In(location)
+			night.WakeTime = session.EndedAt.In(location)
+		}
+	}
+	if len(result) > 0 {
+		finishNight(&result[len(result)-1], ratings)
+	}
+
+	return result
+}
+
+func finishNight(night *Night, ratings []int) {
+	night.TimeInBedMin = reporting.Round(night.TimeInBedMin, 1)
+	night.TotalSleepMin = reporting.Round(night.TotalSleepMin, 1)
+	if night.TimeInBedMin > 0 {
+		night.Efficiency = reporting.Round(night.TotalSleepMin/night.TimeInBedMin*100, 1)
+	}
+	if len(ratings) > 0 {
+		sum := 0
+		for _, rating := range ratings {
+			sum += rating
+		}
+		quality := reporting.Round(float64(sum)/float64(len(ratings)), 1)
+		night.Quality = &quality
+	}
+}
+
+// average summarises nights.
+func average(nights []Night) Averages {
+	averages := Averages{Nights: len(nights)}
+	if len(nights) == 0 {
+		return averages
+	}
+
+	var timeInBed, totalSleep, efficiency, quality []float64
+	var bedtimes, wakeTimes []time.Time
+	for _, night := range nights {
+		timeInBed = append(timeInBed, night.TimeInBedMin)
+		totalSleep = append(totalSleep, night.TotalSleepMin)
+		efficiency = append(efficiency, night.Efficiency)
+		if night.Quality != nil {
+			quality = append(quality, *night.Quality)
+		}
+		bedtimes = append(bedtimes, night.Bedtime)
+		wakeTimes = append(wakeTimes, night.WakeTime)
+	}
+
+	averages.TimeInBedMin = mean(timeInBed)
+	averages.TotalSleepMin = mean(totalSleep)
+	averages.Efficiency = mean(efficiency)
+	averages.Quality = mean(quality)
+	averages.Bedtime, averages.BedtimeSDMin = clockStats(bedtimes)
+	averages.WakeTime, averages.WakeTimeSDMin = clockStats(wakeTimes)
+	return averages
+}
+
+func mean(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	result := reporting.Round(sum/float64(len(values)), 1)
+	return &result
+}
+
+// clockStats returns the circular mean of the times' local clock times and the
+// sample standard deviation in minutes of their distances to it, each wrapped to
+// within 12 hours. Times must already be in the wanted location.
+func clockStats(times []time.Time) (*string, *float64) {
+	if len(times) == 0 {
+		return nil, nil
+	}
+
+	var sumSin, sumCos float64
+	minutes := make([]float64, len(times))
+	for i, t := range times {
+		minutes[i] = float64(t.Hour()*60+t.Minute()) + float64(t.Second())/60
+		angle := minutes[i] / minutesPerDay * 2 * math.Pi
+		sumSin += math.Sin(angle)
+		sumCos += math.Cos(angle)
+	}
+
+	meanMinutes := math.Atan2(sumSin, sumCos) / (2 * math.Pi) * minutesPerDay
+	meanMinutes = math.Mod(meanMinutes+minutesPerDay, minutesPerDay)
+	clock := time.Date(0, 1, 1, 0, int(math.Round(meanMinutes)), 0, 0, time.UTC).Format(clockLayout)
+	if len(times) < 2 {
+		return &clock, nil
+	}
+
+	var sumSquares float64
+	for _, m := range minutes {
+		deviation := math.Mod(m-meanMinutes+1.5*minutesPerDay, minutesPerDay) - minutesPerDay/2
+		sumSquares += deviation * deviation
+	}
+	sd := reporting.Round(math.Sqrt(sumSquares/float64(len(times)-1)), 1)
+	return &clock, &sd
+}
@@ -0,0 +1,56 @@
+package sleep
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockStats(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		times []time.Time
+		clock string
+		sd    float64
+		hasSD bool
+	}{
+		{name: "single night", times: []time.Time{at(1, 23, 15)}, clock: "23:15"},
+		{
+			name:  "evening bedtimes",
+			times: []time.Time{at(1, 22, 0), at(2, 22, 30), at(3, 23, 0)},
+			clock: "22:30", sd: 30, hasSD: true,
+		},
+		{
+			name:  "bedtimes around midnight",
+			times: []time.Time{at(1, 23, 0), at(3, 1, 0)},
+			clock: "00:00", sd: 84.9, hasSD: true,
+		},
+		{
+			name:  "identical wake times",
+			times: []time.Time{at(1, 7, 0), at(2, 7, 0)},
+			clock: "07:00", sd: 0, hasSD: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock, sd := clockStats(tt.times)
+			if clock == nil || *clock != tt.clock {
+				t.Errorf("clock = %v, want %q", clock, tt.clock)
+			}
+			switch {
+			case !tt.hasSD && sd != nil:
+				t.Errorf("sd = %v, want nil", *sd)
+			case tt.hasSD && (sd == nil || *sd != tt.sd):
+				t.Errorf("sd = %v, want %v", sd, tt.sd)
+			}
+		})
+	}
+
+	if clock, sd := clockStats(nil); clock != nil || sd != nil {
+		t.Errorf("clockStats(nil) = %v, %v, want nil, nil", clock, sd)
+	}
+}
@@ -0,0 +1,93 @@
+package sleep
+
+import "time"
+
+// Stage types. Every stage but awake counts as sleep.
+const (
+	StageAwake = "awake"
+	StageLight = "light"
+	StageDeep  = "deep"
+	StageREM   = "rem"
+)
+
+// Session is a period in bed from StartedAt to EndedAt and belongs to the night
+// of the local day it ends on. TimeInBedMin, TotalSleepMin and Efficiency are
+// computed when the session is saved: from the stages if there are any,
+// otherwise by subtracting LatencyMin and AwakeMin from the time in bed.
+type Session struct {
+	ID            string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string    `json:"-" gorm:"type:uuid;not null"`
+	StartedAt     time.Time `json:"started_at" gorm:"not null" validate:"required"`
+	EndedAt       time.Time `json:"ended_at" gorm:"not null" validate:"required,gtfield=StartedAt"`
+	LatencyMin    float64   `json:"latency_min" gorm:"not null;default:0" validate:"gte=0,lte=1440"`
+	AwakeMin      float64   `json:"awake_min" gorm:"not null;default:0" validate:"gte=0,lte=1440"`
+	Quality       *int      `json:"quality" validate:"omitempty,min=1,max=5"`
+	TimeInBedMin  float64   `json:"time_in_bed_min" gorm:"not null"`
+	TotalSleepMin float64   `json:"total_sleep_min" gorm:"not null"`
+	Efficiency    float64   `json:"efficiency" gorm:"not null"`
+	Notes         string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	Stages        []Stage   `json:"stages" gorm:"foreignKey:SessionID" validate:"max=500,dive"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Session) TableName() string {
+	return "sleep_sessions"
+}
+
+// Stage is a hypnogram segment of a session, e.g. from a wearable.
+type Stage struct {
+	ID        string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	SessionID string    `json:"session_id" gorm:"type:uuid;not null"`
+	Type      string    `json:"type" gorm:"not null" validate:"required,oneof=awake light deep rem"`
+	StartedAt time.Time `json:"started_at" gorm:"not null" validate:"required"`
+	EndedAt   time.Time `json:"ended_at" gorm:"not null" validate:"required,gtfield=StartedAt"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Stage) TableName() string {
+	return "sleep_stages"
+}
+
+// Night combines the sessions ending on one local day. Bedtime and WakeTime are
+// those of the longest session, so naps do not shift them.
+type Night struct {
+	Date          string    `json:"date"`
+	Sessions      int       `json:"sessions"`
+	Bedtime       time.Time `json:"bedtime"`
+	WakeTime      time.Time `json:"wake_time"`
+	TimeInBedMin  float64   `json:"time_in_bed_min"`
+	TotalSleepMin float64   `json:"total_sleep_min"`
+	Efficiency    float64   `json:"efficiency"`
+	// Quality is the average rating of the rated sessions, nil if none is rated.
+	Quality *float64 `json:"quality"`
+}
+
+// Averages summarises the nights of a window. Values are nil without data, and
+// the standard deviations need at least two nights. Bedtime and WakeTime are
+// local clock times ("15:04") averaged around the clock, so 23:30 and 00:30
+// average to 00:00.
+type Averages struct {
+	Nights        int      `json:"nights"`
+	TimeInBedMin  *float64 `json:"time_in_bed_min"`
+	TotalSleepMin *float64 `json:"total_sleep_min"`
+	Efficiency    *float64 `json:"efficiency"`
+	Quality       *float64 `json:"quality"`
+	Bedtime       *string  `json:"bedtime"`
+	BedtimeSDMin  *float64 `json:"bedtime_sd_min"`
+	WakeTime      *string  `json:"wake_time"`
+	WakeTimeSDMin *float64 `json:"wake_time_sd_min"`
+}
+
+// Summary is the night of a day together with the averages of the AverageDays
+// nights ending with it.
+type Summary struct {
+	Date     string    `json:"date"`
+	TimeZone string    `json:"time_zone"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Night    *Night    `json:"night"`
+	Nights   []Night   `json:"nights"`
+	Averages Averages  `json:"averages"`
+}
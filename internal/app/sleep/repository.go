@@ -0,0 +1,126 @@
+package sleep
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+type Repository interface {
+	GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error)
+	GetSessionByID(userID, id string) (*Session, error)
+	CreateSession(session *Session) error
+	UpdateSession(session *Session) error
+	DeleteSession(userID, id string) error
+
+	// GetNightSessions returns all of the user's sessions ending in the range
+	// without stages, ordered by their end.
+	GetNightSessions(userID string, period reporting.Range) ([]Session, error)
+	// CountOverlapping counts the user's other sessions overlapping the session's time in bed.
+	CountOverlapping(session *Session) (int64, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error) {
+	var sessions []Session
+	var total int64
+
+	query := inRange(r.db.Model(&Session{}).Where("user_id = ?", userID), period)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Stages", orderByStartedAt).
+		Order("ended_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&sessions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return sessions, total, nil
+}
+
+func (r *repositoryImpl) GetSessionByID(userID, id string) (*Session, error) {
+	var session Session
+	if err := r.db.Preload("Stages", orderByStartedAt).First(&session, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// CreateSession stores the session together with its stages.
+func (r *repositoryImpl) CreateSession(session *Session) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(session).Error; err != nil {
+			return err
+		}
+		return createStages(tx, session)
+	})
+}
+
+// UpdateSession saves the session and replaces its stages.
+func (r *repositoryImpl) UpdateSession(session *Session) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(session).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&Stage{}, "session_id = ?", session.ID).Error; err != nil {
+			return err
+		}
+		return createStages(tx, session)
+	})
+}
+
+func (r *repositoryImpl) DeleteSession(userID, id string) error {
+	return r.db.Delete(&Session{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetNightSessions(userID string, period reporting.Range) ([]Session, error) {
+	var sessions []Session
+	err := inRange(r.db.Model(&Session{}).Where("user_id = ?", userID), period).Order("ended_at, id").Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *repositoryImpl) CountOverlapping(session *Session) (int64, error) {
+	var count int64
+	query := r.db.Model(&Session{}).Where("user_id = ? AND started_at < ? AND ended_at > ?", session.UserID, session.EndedAt, session.StartedAt)
+	if session.ID != "" {
+		query = query.Where("id <> ?", session.ID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+func createStages(tx *gorm.DB, session *Session) error {
+	if len(session.Stages) == 0 {
+		return nil
+	}
+	for i := range session.Stages {
+		session.Stages[i].ID = ""
+		session.Stages[i].SessionID = session.ID
+	}
+	return tx.Create(&session.Stages).Error
+}
+
+func inRange(query *gorm.DB, period reporting.Range) *gorm.DB {
+	if !period.From.IsZero() {
+		query = query.Where("ended_at >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where("ended_at < ?", period.To)
+	}
+	return query
+}
+
+func orderByStartedAt(db *gorm.DB) *gorm.DB {
+	return db.Order("started_at, id")
+}
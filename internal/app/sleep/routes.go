@@ -0,0 +1,17 @@
+package sleep
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/sessions", h.GetSessions)
+	r.Post("/sessions", h.CreateSession)
+	r.Get("/sessions/{id}", h.GetSessionByID)
+	r.Put("/sessions/{id}", h.UpdateSession)
+	r.Delete("/sessions/{id}", h.DeleteSession)
+
+	r.Get("/days/{date}/summary", h.GetSummary)
+
+	return r
+}
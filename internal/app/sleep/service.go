@@ -0,0 +1,112 @@
+package sleep
+
+import (
+	"errors"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+var ErrSessionOverlap = errors.New("sleep session overlaps another session")
+
+type Service interface {
+	GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error)
+	GetSessionByID(userID, id string) (*Session, error)
+	CreateSession(userID string, session *Session) error
+	UpdateSession(userID string, session *Session) error
+	DeleteSession(userID, id string) error
+
+	// GetSummary returns the user's night ending on the day starting at the given
+	// local midnight and the averages of the AverageDays nights up to it.
+	GetSummary(userID string, day time.Time) (*Summary, error)
+}
+
+type serviceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetSessions(userID string, period reporting.Range, limit, offset int) ([]Session, int64, error) {
+	return s.repo.GetSessions(userID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetSessionByID(userID, id string) (*Session, error) {
+	return s.repo.GetSessionByID(userID, id)
+}
+
+func (s *serviceImpl) CreateSession(userID string, session *Session) error {
+	session.UserID = userID
+	if err := s.prepare(session); err != nil {
+		return err
+	}
+
+	return s.repo.CreateSession(session)
+}
+
+func (s *serviceImpl) UpdateSession(userID string, session *Session) error {
+	existingSession, err := s.repo.GetSessionByID(userID, session.ID)
+	if err != nil {
+		return err
+	}
+
+	session.UserID = userID
+	if err := s.prepare(session); err != nil {
+		return err
+	}
+
+	session.CreatedAt = existingSession.CreatedAt
+	return s.repo.UpdateSession(session)
+}
+
+func (s *serviceImpl) DeleteSession(userID, id string) error {
+	if _, err := s.repo.GetSessionByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteSession(userID, id)
+}
+
+func (s *serviceImpl) GetSummary(userID string, day time.Time) (*Summary, error) {
+	location := day.Location()
+	period := reporting.Range{From: day.AddDate(0, 0, 1-AverageDays), To: day.AddDate(0, 0, 1)}
+
+	sessions, err := s.repo.GetNightSessions(userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Date:     day.Format(dateLayout),
+		TimeZone: location.String(),
+		From:     period.From,
+		To:       period.To,
+		Nights:   nights(sessions, location),
+	}
+	if summary.Nights == nil {
+		summary.Nights = []Night{}
+	}
+	if last := len(summary.Nights) - 1; last >= 0 && summary.Nights[last].Date == summary.Date {
+		summary.Night = &summary.Nights[last]
+	}
+	summary.Averages = average(summary.Nights)
+
+	return summary, nil
+}
+
+// prepare computes the session's metrics and rejects sessions overlapping another one.
+func (s *serviceImpl) prepare(session *Session) error {
+	if err := measure(session); err != nil {
+		return err
+	}
+
+	overlapping, err := s.repo.CountOverlapping(session)
+	if err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return ErrSessionOverlap
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package sleep
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// fakeRepository holds one session of owner; the writes are recorded.
+type fakeRepository struct {
+	Repository
+	session Session
+	owner   string
+	writes  []string
+}
+
+func (r *fakeRepository) GetSessionByID(userID, id string) (*Session, error) {
+	if id != r.session.ID || userID != r.owner {
+		return nil, gorm.ErrRecordNotFound
+	}
+	session := r.session
+	return &session, nil
+}
+
+func (r *fakeRepository) CountOverlapping(session *Session) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeRepository) UpdateSession(session *Session) error {
+	r.writes = append(r.writes, "UpdateSession")
+	return nil
+}
+
+func (r *fakeRepository) DeleteSession(userID, id string) error {
+	r.writes = append(r.writes, "DeleteSession")
+	return nil
+}
+
+func TestSessionsOfAnotherUser(t *testing.T) {
+	night := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	repo := &fakeRepository{session: Session{ID: "night-1", StartedAt: night, EndedAt: night.Add(8 * time.Hour)}, owner: "owner"}
+	service := NewService(repo)
+	// The update replaces the session's stages.
+	update := func() *Session {
+		return &Session{ID: "night-1", StartedAt: night, EndedAt: night.Add(7 * time.Hour),
+			Stages: []Stage{{Type: StageDeep, StartedAt: night.Add(time.Hour), EndedAt: night.Add(2 * time.Hour)}}}
+	}
+
+	tests := []struct {
+		name string
+		call func(userID string) error
+	}{
+		{name: "GetSessionByID", call: func(userID string) error {
+			_, err := service.GetSessionByID(userID, "night-1")
+			return err
+		}},
+		{name: "UpdateSession", call: func(userID string) error { return service.UpdateSession(userID, update()) }},
+		{name: "DeleteSession", call: func(userID string) error { return service.DeleteSession(userID, "night-1") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.writes = nil
+			if err := tt.call("intruder"); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Fatalf("%s() by another user error = %v, want %v", tt.name, err, gorm.ErrRecordNotFound)
+			}
+			if len(repo.writes) > 0 {
+				t.Errorf("%s() by another user wrote %v", tt.name, repo.writes)
+			}
+			if err := tt.call("owner"); err != nil {
+				t.Errorf("%s() by the owner error = %v", tt.name, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package symptom
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo)
+	validator := validator.New()
+	symptomLogger := logger.Named("SymptomHandler")
+
+	return NewHandler(service, validator, symptomLogger)
+}
@@ -0,0 +1,319 @@
+package symptom
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultTriggerDays is the length of a trigger analysis requested without "from".
+const defaultTriggerDays = 90
+
+// defaultWindowHours is the time after a meal symptoms are attributed to it
+// without "window_hours"; maxWindowHours bounds it.
+const (
+	defaultWindowHours = 6
+	maxWindowHours     = 72
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			h.Logger.Warn("Invalid 'limit' parameter", zap.String("limit", l))
+			return
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'offset' parameter")
+			h.Logger.Warn("Invalid 'offset' parameter", zap.String("offset", o))
+			return
+		}
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+
+	minSeverity, ok := h.parseMinSeverity(w, r, 0)
+	if !ok {
+		return
+	}
+
+	filter := Filter{Range: period, Type: r.URL.Query().Get("type"), MinSeverity: minSeverity}
+	symptoms, total, err := h.Service.GetAll(userID, filter, limit, offset)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving symptoms")
+		h.Logger.Error("Error retrieving symptoms", zap.Error(err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":     symptoms,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"returned": len(symptoms),
+	}
+
+	h.Logger.Info("Retrieved symptoms", zap.Int("limit", limit), zap.Int("offset", offset), zap.Int("returned", len(symptoms)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var symptom Symptom
+	if err := json.NewDecoder(r.Body).Decode(&symptom); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(symptom); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	if err := h.Service.Create(userID, &symptom); err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error creating symptom")
+		h.Logger.Error("Error creating symptom", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Created new symptom", zap.String("id", symptom.ID), zap.String("type", symptom.Type), zap.Intp("severity", symptom.Severity))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(symptom); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing symptom ID")
+		h.Logger.Warn("Missing symptom ID in request")
+		return
+	}
+
+	symptom, err := h.Service.GetByID(userID, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Symptom not found")
+			h.Logger.Warn("Symptom not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving symptom")
+		h.Logger.Error("Error retrieving symptom", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Retrieved symptom", zap.String("id", symptom.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(symptom); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing symptom ID")
+		h.Logger.Warn("Missing symptom ID in request")
+		return
+	}
+
+	var updatedData Symptom
+	if err := json.NewDecoder(r.Body).Decode(&updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(updatedData); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	updatedData.ID = id
+	if err := h.Service.Update(userID, &updatedData); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Symptom not found")
+			h.Logger.Warn("Symptom not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error updating symptom")
+		h.Logger.Error("Error updating symptom", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Updated symptom", zap.String("id", updatedData.ID))
+	if err := json.NewEncoder(w).Encode(updatedData); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Missing symptom ID")
+		h.Logger.Warn("Missing symptom ID in request")
+		return
+	}
+
+	if err := h.Service.Delete(userID, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			errors.WriteHTTPError(w, http.StatusNotFound, "Symptom not found")
+			h.Logger.Warn("Symptom not found", zap.String("id", id))
+			return
+		}
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error deleting symptom")
+		h.Logger.Error("Error deleting symptom", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Deleted symptom", zap.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetTriggers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := reporting.ParseLocation(w, r, h.Logger)
+	if !ok {
+		return
+	}
+
+	period, ok := reporting.ParseRange(w, r, location, h.Logger)
+	if !ok {
+		return
+	}
+	if period.To.IsZero() {
+		now := time.Now().In(location)
+		period.To = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, location)
+	}
+	if period.From.IsZero() {
+		period.From = period.To.AddDate(0, 0, -defaultTriggerDays)
+	}
+
+	windowHours := float64(defaultWindowHours)
+	if wh := r.URL.Query().Get("window_hours"); wh != "" {
+		if parsed, err := strconv.ParseFloat(wh, 64); err == nil && parsed > 0 && parsed <= maxWindowHours {
+			windowHours = parsed
+		} else {
+			errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("Invalid 'window_hours' parameter, expected a number up to %d", maxWindowHours))
+			h.Logger.Warn("Invalid 'window_hours' parameter", zap.String("window_hours", wh))
+			return
+		}
+	}
+
+	minSeverity, ok := h.parseMinSeverity(w, r, 1)
+	if !ok {
+		return
+	}
+
+	query := TriggerQuery{
+		Range:       period,
+		Window:      time.Duration(windowHours * float64(time.Hour)),
+		Type:        r.URL.Query().Get("type"),
+		MinSeverity: minSeverity,
+	}
+
+	report, err := h.Service.GetTriggers(userID, query)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusInternalServerError, "Error analysing symptom triggers")
+		h.Logger.Error("Error analysing symptom triggers", zap.Error(err))
+		return
+	}
+
+	h.Logger.Info("Analysed symptom triggers", zap.Time("from", period.From), zap.Time("to", period.To), zap.Int("meals", report.Meals), zap.Int("foods", len(report.Foods)))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+// parseMinSeverity reads the optional "min_severity" query parameter.
+func (h *Handler) parseMinSeverity(w http.ResponseWriter, r *http.Request, fallback int) (int, bool) {
+	value := r.URL.Query().Get("min_severity")
+	if value == "" {
+		return fallback, true
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 || parsed > 10 {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'min_severity' parameter, expected 0 to 10")
+		h.Logger.Warn("Invalid 'min_severity' parameter", zap.String("min_severity", value))
+		return 0, false
+	}
+	return parsed, true
+}
@@ -0,0 +1,66 @@
+package symptom
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// Symptom is an occurrence of a symptom such as bloating or a headache. Type
+// is free text, stored in lower case so that entries group together.
+type Symptom struct {
+	ID          string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID      string    `json:"-" gorm:"type:uuid;not null"`
+	Type        string    `json:"type" gorm:"not null" validate:"required,min=1,max=50"`
+	Severity    *int      `json:"severity" gorm:"not null" validate:"required,min=0,max=10"`
+	OnsetAt     time.Time `json:"onset_at" gorm:"not null" validate:"required"`
+	DurationMin *float64  `json:"duration_min" validate:"omitempty,gt=0,lte=10080"`
+	Notes       string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Filter narrows symptom queries. Empty fields match everything.
+type Filter struct {
+	reporting.Range
+	Type        string
+	MinSeverity int
+}
+
+// TriggerQuery selects the meals and symptoms a trigger analysis correlates.
+type TriggerQuery struct {
+	// reporting.Range limits the meals; symptoms up to Window after the last meal count.
+	reporting.Range
+	Window      time.Duration
+	Type        string
+	MinSeverity int
+}
+
+// Trigger compares how often meals with a food are followed by a symptom
+// within the window with how often the other meals are. Rates are nil without
+// meals, and Lift is nil while the baseline rate is zero.
+type Trigger struct {
+	FoodID           string   `json:"food_id"`
+	Name             string   `json:"name"`
+	Exposures        int      `json:"exposures"`
+	Followed         int      `json:"followed"`
+	Rate             *float64 `json:"rate"`
+	BaselineMeals    int      `json:"baseline_meals"`
+	BaselineFollowed int      `json:"baseline_followed"`
+	BaselineRate     *float64 `json:"baseline_rate"`
+	Lift             *float64 `json:"lift"`
+	Confidence       string   `json:"confidence"`
+}
+
+// TriggerReport ranks the foods eaten in a period as symptom triggers, most
+// likely first.
+type TriggerReport struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	WindowHours   float64   `json:"window_hours"`
+	Type          string    `json:"type"`
+	MinSeverity   int       `json:"min_severity"`
+	Meals         int       `json:"meals"`
+	MealsFollowed int       `json:"meals_followed"`
+	Foods         []Trigger `json:"foods"`
+}
@@ -0,0 +1,113 @@
+package symptom
+
+import "gorm.io/gorm"
+
+// triggersSQL flags every meal of a user in a range that is followed by a
+// matching symptom of theirs within the window, then counts the flagged meals
+// per food and, in the row without a food, over all meals with entries.
+const triggersSQL = `
+WITH flagged AS (SELECT m.id,
+                        EXISTS (SELECT 1
+                                FROM symptoms s
+                                WHERE s.user_id = m.user_id
+                                  AND s.onset_at > m.consumed_at
+                                  AND s.onset_at <= m.consumed_at + make_interval(secs => ?)
+                                  AND s.severity >= ?
+                                  AND (?::text = '' OR s.type = ?)) AS followed
+                 FROM meals m
+                 WHERE m.user_id = ?
+                   AND m.consumed_at >= ?
+                   AND m.consumed_at < ?)
+SELECT f.id                                             AS food_id,
+       f.name,
+       COUNT(DISTINCT fl.id)                            AS exposures,
+       COUNT(DISTINCT fl.id) FILTER (WHERE fl.followed) AS followed
+FROM flagged fl
+         JOIN diary_entries e ON e.meal_id = fl.id
+         JOIN foods f ON f.id = e.food_id
+GROUP BY GROUPING SETS ((f.id, f.name), ())
+ORDER BY f.name NULLS FIRST`
+
+// TriggerRow is a row of triggersSQL. The food fields are nil in the row
+// counting all meals.
+type TriggerRow struct {
+	FoodID    *string
+	Name      *string
+	Exposures int
+	Followed  int
+}
+
+type Repository interface {
+	GetAll(userID string, filter Filter, limit, offset int) ([]Symptom, int64, error)
+	GetByID(userID, id string) (*Symptom, error)
+	Create(symptom *Symptom) error
+	Update(symptom *Symptom) error
+	Delete(userID, id string) error
+	GetTriggerCounts(userID string, query TriggerQuery) ([]TriggerRow, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(userID string, filter Filter, limit, offset int) ([]Symptom, int64, error) {
+	var symptoms []Symptom
+	var total int64
+
+	query := r.db.Model(&Symptom{}).Where("user_id = ?", userID)
+	if !filter.From.IsZero() {
+		query = query.Where("onset_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("onset_at < ?", filter.To)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.MinSeverity > 0 {
+		query = query.Where("severity >= ?", filter.MinSeverity)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("onset_at DESC").Limit(limit).Offset(offset).Find(&symptoms).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return symptoms, total, nil
+}
+
+func (r *repositoryImpl) GetByID(userID, id string) (*Symptom, error) {
+	var symptom Symptom
+	if err := r.db.First(&symptom, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &symptom, nil
+}
+
+func (r *repositoryImpl) Create(symptom *Symptom) error {
+	return r.db.Create(symptom).Error
+}
+
+func (r *repositoryImpl) Update(symptom *Symptom) error {
+	return r.db.Save(symptom).Error
+}
+
+func (r *repositoryImpl) Delete(userID, id string) error {
+	return r.db.Delete(&Symptom{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetTriggerCounts(userID string, query TriggerQuery) ([]TriggerRow, error) {
+	var rows []TriggerRow
+	err := r.db.Raw(triggersSQL,
+		query.Window.Seconds(), query.MinSeverity, query.Type, query.Type,
+		userID, query.From, query.To,
+	).Scan(&rows).Error
+	return rows, err
+}
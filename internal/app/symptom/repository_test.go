@@ -0,0 +1,31 @@
+package symptom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db/dbtest"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+func TestGetTriggerCountsScopesToUser(t *testing.T) {
+	database, recorder := dbtest.Open(t)
+	query := TriggerQuery{
+		Range:  reporting.Range{From: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		Window: 6 * time.Hour,
+	}
+
+	// A dry run finds no rows, so only the statement is checked.
+	_, _ = NewRepository(database).GetTriggerCounts("user-1", query)
+
+	if len(recorder.Statements) != 1 {
+		t.Fatalf("sent %d statements, want 1", len(recorder.Statements))
+	}
+	statement := recorder.Statements[0]
+	for _, scope := range []string{"m.user_id = 'user-1'", "s.user_id = m.user_id"} {
+		if !strings.Contains(statement, scope) {
+			t.Errorf("statement lacks %q: %s", scope, statement)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package symptom
+
+import "strings"
+
+type Service interface {
+	GetAll(userID string, filter Filter, limit, offset int) ([]Symptom, int64, error)
+	GetByID(userID, id string) (*Symptom, error)
+	Create(userID string, symptom *Symptom) error
+	Update(userID string, symptom *Symptom) error
+	Delete(userID, id string) error
+
+	// GetTriggers correlates the foods of the user's meals in the range with the
+	// symptoms following them.
+	GetTriggers(userID string, query TriggerQuery) (*TriggerReport, error)
+}
+
+type serviceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetAll(userID string, filter Filter, limit, offset int) ([]Symptom, int64, error) {
+	filter.Type = normalizeType(filter.Type)
+	return s.repo.GetAll(userID, filter, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(userID, id string) (*Symptom, error) {
+	return s.repo.GetByID(userID, id)
+}
+
+func (s *serviceImpl) Create(userID string, symptom *Symptom) error {
+	symptom.UserID = userID
+	symptom.Type = normalizeType(symptom.Type)
+	return s.repo.Create(symptom)
+}
+
+func (s *serviceImpl) Update(userID string, symptom *Symptom) error {
+	existingSymptom, err := s.repo.GetByID(userID, symptom.ID)
+	if err != nil {
+		return err
+	}
+
+	symptom.UserID = userID
+	symptom.Type = normalizeType(symptom.Type)
+	symptom.CreatedAt = existingSymptom.CreatedAt
+	return s.repo.Update(symptom)
+}
+
+func (s *serviceImpl) Delete(userID, id string) error {
+	if _, err := s.repo.GetByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(userID, id)
+}
+
+func (s *serviceImpl) GetTriggers(userID string, query TriggerQuery) (*TriggerReport, error) {
+	query.Type = normalizeType(query.Type)
+
+	rows, err := s.repo.GetTriggerCounts(userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TriggerReport{
+		From:        query.From,
+		To:          query.To,
+		WindowHours: query.Window.Hours(),
+		Type:        query.Type,
+		MinSeverity: query.MinSeverity,
+	}
+
+	var foods []TriggerRow
+	for _, row := range rows {
+		if row.FoodID == nil {
+			report.Meals = row.Exposures
+			report.MealsFollowed = row.Followed
+			continue
+		}
+		foods = append(foods, row)
+	}
+	report.Foods = rankTriggers(foods, report.Meals, report.MealsFollowed)
+
+	return report, nil
+}
+
+func normalizeType(symptomType string) string {
+	return strings.ToLower(strings.TrimSpace(symptomType))
+}
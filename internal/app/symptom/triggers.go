@@ -0,0 +1,80 @@
+package symptom
+
+import (
+	"sort"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// Confidence levels of a trigger, from the meals behind both of its rates.
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// Meals needed on both sides of a comparison for a medium or high confidence.
+const (
+	mediumConfidenceMeals = 5
+	highConfidenceMeals   = 15
+)
+
+// rankTriggers turns the per-food counts into triggers compared with the meals
+// without the food, ordered by lift, then by exposures.
+func rankTriggers(rows []TriggerRow, meals, mealsFollowed int) []Trigger {
+	triggers := []Trigger{}
+	for _, row := range rows {
+		trigger := Trigger{
+			FoodID:           *row.FoodID,
+			Name:             *row.Name,
+			Exposures:        row.Exposures,
+			Followed:         row.Followed,
+			Rate:             rate(row.Followed, row.Exposures),
+			BaselineMeals:    meals - row.Exposures,
+			BaselineFollowed: mealsFollowed - row.Followed,
+		}
+		trigger.BaselineRate = rate(trigger.BaselineFollowed, trigger.BaselineMeals)
+		if trigger.Exposures > 0 && trigger.BaselineFollowed > 0 {
+			exposed := float64(trigger.Followed) / float64(trigger.Exposures)
+			baseline := float64(trigger.BaselineFollowed) / float64(trigger.BaselineMeals)
+			lift := reporting.Round(exposed/baseline, 2)
+			trigger.Lift = &lift
+		}
+		trigger.Confidence = confidence(min(trigger.Exposures, trigger.BaselineMeals))
+		triggers = append(triggers, trigger)
+	}
+
+	sort.SliceStable(triggers, func(i, j int) bool {
+		a, b := triggers[i], triggers[j]
+		if (a.Lift == nil) != (b.Lift == nil) {
+			return a.Lift != nil
+		}
+		if a.Lift != nil && *a.Lift != *b.Lift {
+			return *a.Lift > *b.Lift
+		}
+		if a.Exposures != b.Exposures {
+			return a.Exposures > b.Exposures
+		}
+		return a.Name < b.Name
+	})
+	return triggers
+}
+
+func confidence(meals int) string {
+	switch {
+	case meals >= highConfidenceMeals:
+		return ConfidenceHigh
+	case meals >= mediumConfidenceMeals:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+func rate(part, whole int) *float64 {
+	if whole <= 0 {
+		return nil
+	}
+	value := reporting.Round(float64(part)/float64(whole), 3)
+	return &value
+}
@@ -0,0 +1,78 @@
+package symptom
+
+import "testing"
+
+func TestRankTriggers(t *testing.T) {
+	row := func(name string, exposures, followed int) TriggerRow {
+		id := name + "-id"
+		return TriggerRow{FoodID: &id, Name: &name, Exposures: exposures, Followed: followed}
+	}
+	rows := []TriggerRow{
+		row("apple", 4, 0),
+		row("bread", 20, 5),
+		row("everything", 40, 10),
+		row("oat", 10, 6),
+		row("milk", 10, 6),
+		row("rice", 20, 8),
+	}
+
+	triggers := rankTriggers(rows, 40, 10)
+
+	want := []struct {
+		name         string
+		lift         *float64
+		rate         float64
+		baselineRate *float64
+		confidence   string
+	}{
+		{name: "milk", lift: ptr(4.5), rate: 0.6, baselineRate: ptr(0.133), confidence: ConfidenceMedium},
+		{name: "oat", lift: ptr(4.5), rate: 0.6, baselineRate: ptr(0.133), confidence: ConfidenceMedium},
+		{name: "rice", lift: ptr(4), rate: 0.4, baselineRate: ptr(0.1), confidence: ConfidenceHigh},
+		{name: "bread", lift: ptr(1), rate: 0.25, baselineRate: ptr(0.25), confidence: ConfidenceHigh},
+		{name: "apple", lift: ptr(0), rate: 0, baselineRate: ptr(0.278), confidence: ConfidenceLow},
+		{name: "everything", rate: 0.25, confidence: ConfidenceLow},
+	}
+	if len(triggers) != len(want) {
+		t.Fatalf("got %d triggers, want %d", len(triggers), len(want))
+	}
+	for i, w := range want {
+		got := triggers[i]
+		if got.Name != w.name {
+			t.Fatalf("triggers[%d] = %q, want %q", i, got.Name, w.name)
+		}
+		if !equal(got.Lift, w.lift) {
+			t.Errorf("%s: Lift = %v, want %v", w.name, got.Lift, w.lift)
+		}
+		if got.Rate == nil || *got.Rate != w.rate {
+			t.Errorf("%s: Rate = %v, want %v", w.name, got.Rate, w.rate)
+		}
+		if !equal(got.BaselineRate, w.baselineRate) {
+			t.Errorf("%s: BaselineRate = %v, want %v", w.name, got.BaselineRate, w.baselineRate)
+		}
+		if got.Confidence != w.confidence {
+			t.Errorf("%s: Confidence = %q, want %q", w.name, got.Confidence, w.confidence)
+		}
+	}
+}
+
+func TestRankTriggersWithoutSymptoms(t *testing.T) {
+	name, id := "milk", "milk-id"
+	triggers := rankTriggers([]TriggerRow{{FoodID: &id, Name: &name, Exposures: 3}}, 8, 0)
+	if triggers[0].Lift != nil {
+		t.Errorf("Lift = %v, want nil without symptoms to compare with", *triggers[0].Lift)
+	}
+	if triggers[0].BaselineMeals != 5 || triggers[0].BaselineFollowed != 0 {
+		t.Errorf("baseline = %d meals, %d followed, want 5, 0", triggers[0].BaselineMeals, triggers[0].BaselineFollowed)
+	}
+}
+
+func ptr(value float64) *float64 {
+	return &value
+}
+
+func equal(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
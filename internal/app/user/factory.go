@@ -0,0 +1,16 @@
+package user
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo, logger.Named("UserService"))
+	validator := validator.New()
+	userLogger := logger.Named("UserHandler")
+
+	return NewHandler(service, validator, userLogger)
+}
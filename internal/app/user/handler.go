@@ -0,0 +1,116 @@
+package user
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type Handler struct {
+	Service   Service
+	Validator *validator.Validate
+	Logger    *zap.Logger
+}
+
+func NewHandler(service Service, validator *validator.Validate, logger *zap.Logger) *Handler {
+	return &Handler{
+		Service:   service,
+		Validator: validator,
+		Logger:    logger,
+	}
+}
+
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	var registration Registration
+	if err := json.NewDecoder(r.Body).Decode(&registration); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(registration); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed", zap.Error(err))
+		return
+	}
+
+	user, err := h.Service.Register(&registration)
+	if err != nil {
+		h.writeUserError(w, err, "", "Error registering user")
+		return
+	}
+
+	h.Logger.Info("Registered new user", zap.String("id", user.ID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	id, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.Service.GetByID(id)
+	if err != nil {
+		h.writeUserError(w, err, id, "Error retrieving user")
+		return
+	}
+
+	h.Logger.Info("Retrieved user", zap.String("id", user.ID))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	id, ok := auth.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var profile Profile
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid JSON input")
+		h.Logger.Warn("Invalid JSON input for update", zap.Error(err))
+		return
+	}
+
+	if err := h.Validator.Struct(profile); err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Validation failed")
+		h.Logger.Warn("Validation failed for update", zap.Error(err))
+		return
+	}
+
+	user, err := h.Service.UpdateProfile(id, &profile)
+	if err != nil {
+		h.writeUserError(w, err, id, "Error updating user")
+		return
+	}
+
+	h.Logger.Info("Updated user", zap.String("id", user.ID))
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		h.Logger.Error("Failed to encode response", zap.Error(err))
+	}
+}
+
+func (h *Handler) writeUserError(w http.ResponseWriter, err error, id, message string) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		errors.WriteHTTPError(w, http.StatusNotFound, "User not found")
+		h.Logger.Warn("User not found", zap.String("id", id))
+	case ErrEmailTaken:
+		errors.WriteHTTPError(w, http.StatusConflict, "Email is already registered")
+		h.Logger.Warn("Email is already registered")
+	default:
+		errors.WriteHTTPError(w, http.StatusInternalServerError, message)
+		h.Logger.Error(message, zap.String("id", id), zap.Error(err))
+	}
+}
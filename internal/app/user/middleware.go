@@ -0,0 +1,31 @@
+package user
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/auth"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// DefaultLocation makes the time zone of the signed-in user's profile the
+// default "tz" of the request. It belongs after the auth middleware; the
+// profile is only loaded when a handler asks for the default.
+func (h *Handler) DefaultLocation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.PrincipalFrom(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := reporting.WithDefaultLocation(r.Context(), func() (*time.Location, error) {
+			user, err := h.Service.GetByID(principal.UserID)
+			if err != nil {
+				return nil, err
+			}
+			return time.LoadLocation(user.TimeZone)
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
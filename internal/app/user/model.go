@@ -0,0 +1,38 @@
+package user
+
+import "time"
+
+// User is an account. Email is unique regardless of case; the password hash,
+// failed login count and lock are never encoded.
+type User struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Email        string     `json:"email" gorm:"not null"`
+	PasswordHash string     `json:"-" gorm:"not null"`
+	Name         string     `json:"name" gorm:"not null;default:''"`
+	TimeZone     string     `json:"time_zone" gorm:"not null;default:'UTC'"`
+	FailedLogins int        `json:"-" gorm:"not null;default:0"`
+	LockedUntil  *time.Time `json:"-"`
+	LastLoginAt  *time.Time `json:"last_login_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Registration is the body of a sign-up request.
+type Registration struct {
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,min=8,max=128"`
+	Name     string `json:"name" validate:"max=100"`
+	TimeZone string `json:"time_zone" validate:"omitempty,timezone"`
+}
+
+// Credentials is the body of a login request.
+type Credentials struct {
+	Email    string `json:"email" validate:"required,max=254"`
+	Password string `json:"password" validate:"required,max=128"`
+}
+
+// Profile holds the fields a user may change about themselves.
+type Profile struct {
+	Name     string `json:"name" validate:"max=100"`
+	TimeZone string `json:"time_zone" validate:"omitempty,timezone"`
+}
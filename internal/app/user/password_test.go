@@ -0,0 +1,73 @@
+package user
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := hashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$") {
+		t.Errorf("hashPassword() = %q, want argon2id with the default parameters", hash)
+	}
+
+	other, err := hashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == other {
+		t.Error("hashPassword() returned the same hash twice, want a fresh salt")
+	}
+}
+
+func TestVerifyPassword(t *testing.T) {
+	hash, err := hashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A hash with weaker parameters, as stored before they were raised.
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte("battery staple"), salt, 1, 1024, 1, 32)
+	legacy := fmt.Sprintf("$argon2id$v=%d$m=1024,t=1,p=1$%s$%s", argon2.Version,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		want     bool
+		err      error
+	}{
+		{name: "match", password: "correct horse", hash: hash, want: true},
+		{name: "wrong password", password: "correct horse!", hash: hash},
+		{name: "empty password", password: "", hash: hash},
+		{name: "parameters from the hash", password: "battery staple", hash: legacy, want: true},
+		{name: "not argon2id", password: "x", hash: "$2a$10$abcdefghijklmnopqrstuv", err: errInvalidHash},
+		{name: "argon2i", password: "x", hash: strings.Replace(hash, "argon2id", "argon2i", 1), err: errInvalidHash},
+		{name: "other version", password: "x", hash: strings.Replace(hash, "v=19", "v=16", 1), err: errInvalidHash},
+		{name: "bad parameters", password: "x", hash: strings.Replace(hash, "m=65536", "m=lots", 1), err: errInvalidHash},
+		{name: "bad salt", password: "x", hash: "$argon2id$v=19$m=1024,t=1,p=1$!!$AAAA", err: errInvalidHash},
+		{name: "empty", password: "x", hash: "", err: errInvalidHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyPassword(tt.password, tt.hash)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("verifyPassword() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("verifyPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetByID(id string) (*User, error)
+	GetByEmail(email string) (*User, error)
+	Create(user *User) error
+	UpdateProfile(user *User) error
+	RecordFailedLogin(id string, maxAttempts int, lockedUntil time.Time) error
+	RecordLogin(id string, at time.Time) error
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetByID(id string) (*User, error) {
+	var user User
+	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByEmail finds a user by email regardless of case.
+func (r *repositoryImpl) GetByEmail(email string) (*User, error) {
+	var user User
+	if err := r.db.First(&user, "lower(email) = lower(?)", email).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *repositoryImpl) Create(user *User) error {
+	return r.db.Create(user).Error
+}
+
+// UpdateProfile saves the profile fields only, leaving the password and login
+// state to the statements that manage them.
+func (r *repositoryImpl) UpdateProfile(user *User) error {
+	return r.db.Model(user).Select("Name", "TimeZone").Updates(user).Error
+}
+
+// RecordFailedLogin counts a failed login in one statement, so that concurrent
+// attempts are all counted. Reaching maxAttempts locks the account until
+// lockedUntil and starts counting afresh.
+func (r *repositoryImpl) RecordFailedLogin(id string, maxAttempts int, lockedUntil time.Time) error {
+	return r.db.Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_logins": gorm.Expr("CASE WHEN failed_logins + 1 >= ? THEN 0 ELSE failed_logins + 1 END", maxAttempts),
+		"locked_until":  gorm.Expr("CASE WHEN failed_logins + 1 >= ? THEN ?::timestamptz ELSE locked_until END", maxAttempts, lockedUntil),
+	}).Error
+}
+
+// RecordLogin resets the failed login count and lock after a successful login.
+func (r *repositoryImpl) RecordLogin(id string, at time.Time) error {
+	return r.db.Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_logins": 0,
+		"locked_until":  nil,
+		"last_login_at": at,
+	}).Error
+}
@@ -0,0 +1,22 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Routes serves registration publicly and the caller's profile behind authenticate.
+func (h *Handler) Routes(authenticate func(http.Handler) http.Handler) chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/register", h.Register)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Get("/me", h.GetProfile)
+		r.Put("/me", h.UpdateProfile)
+	})
+
+	return r
+}
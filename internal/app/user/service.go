@@ -0,0 +1,162 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// MaxFailedLogins consecutive failed logins lock an account for LockoutDuration.
+const (
+	MaxFailedLogins = 5
+	LockoutDuration = 15 * time.Minute
+)
+
+var (
+	ErrEmailTaken         = errors.New("email is already registered")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountLocked      = errors.New("account is locked")
+)
+
+// RegistrationListener is notified after an account has been created, e.g. to
+// seed its defaults. The account is already stored, so listener errors are
+// logged rather than returned.
+type RegistrationListener interface {
+	UserRegistered(user *User) error
+}
+
+type Service interface {
+	Register(registration *Registration) (*User, error)
+	// Login checks the credentials. Failed attempts count towards a lockout,
+	// during which even the right password is refused.
+	Login(credentials *Credentials) (*User, error)
+	GetByID(id string) (*User, error)
+	UpdateProfile(id string, profile *Profile) (*User, error)
+
+	Subscribe(listener RegistrationListener)
+}
+
+type serviceImpl struct {
+	repo Repository
+	// dummyHash is verified against for unknown emails, so that they take as
+	// long to reject as wrong passwords.
+	dummyHash string
+	listeners []RegistrationListener
+	logger    *zap.Logger
+}
+
+func NewService(repo Repository, logger *zap.Logger) Service {
+	dummyHash, err := hashPassword("not a password")
+	if err != nil {
+		panic(err)
+	}
+	return &serviceImpl{repo: repo, dummyHash: dummyHash, logger: logger}
+}
+
+func (s *serviceImpl) Register(registration *Registration) (*User, error) {
+	email := normalizeEmail(registration.Email)
+	if _, err := s.repo.GetByEmail(email); err == nil {
+		return nil, ErrEmailTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	hash, err := hashPassword(registration.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &User{
+		Email:        email,
+		PasswordHash: hash,
+		Name:         strings.TrimSpace(registration.Name),
+		TimeZone:     registration.TimeZone,
+	}
+	if user.TimeZone == "" {
+		user.TimeZone = "UTC"
+	}
+
+	if err := s.repo.Create(user); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEmailTaken
+		}
+		return nil, err
+	}
+
+	for _, listener := range s.listeners {
+		if err := listener.UserRegistered(user); err != nil {
+			s.logger.Error("Registration listener failed", zap.String("id", user.ID), zap.Error(err))
+		}
+	}
+	return user, nil
+}
+
+func (s *serviceImpl) Login(credentials *Credentials) (*User, error) {
+	user, err := s.repo.GetByEmail(normalizeEmail(credentials.Email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if _, err := verifyPassword(credentials.Password, s.dummyHash); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify before checking the lockout, so a locked account answers as slowly as any other.
+	ok, err := verifyPassword(credentials.Password, user.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return nil, ErrAccountLocked
+	}
+	if !ok {
+		if err := s.repo.RecordFailedLogin(user.ID, MaxFailedLogins, now.Add(LockoutDuration)); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.repo.RecordLogin(user.ID, now); err != nil {
+		return nil, err
+	}
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+	user.LastLoginAt = &now
+	return user, nil
+}
+
+func (s *serviceImpl) GetByID(id string) (*User, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *serviceImpl) UpdateProfile(id string, profile *Profile) (*User, error) {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Name = strings.TrimSpace(profile.Name)
+	if profile.TimeZone != "" {
+		user.TimeZone = profile.TimeZone
+	}
+	if err := s.repo.UpdateProfile(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *serviceImpl) Subscribe(listener RegistrationListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// normalizeEmail trims an email; case is kept as entered and ignored in lookups.
+func normalizeEmail(email string) string {
+	return strings.TrimSpace(email)
+}
@@ -0,0 +1,38 @@
+package vitals
+
+// Blood pressure categories of the 2017 ACC/AHA guideline.
+const (
+	BPNormal             = "normal"
+	BPElevated           = "elevated"
+	BPHypertensionStage1 = "hypertension_stage_1"
+	BPHypertensionStage2 = "hypertension_stage_2"
+	BPHypertensiveCrisis = "hypertensive_crisis"
+)
+
+// classify returns the category of a blood pressure. When systolic and
+// diastolic fall into different categories, the higher one applies.
+func classify(systolic, diastolic float64) string {
+	switch {
+	case systolic > 180 || diastolic > 120:
+		return BPHypertensiveCrisis
+	case systolic >= 140 || diastolic >= 90:
+		return BPHypertensionStage2
+	case systolic >= 130 || diastolic >= 80:
+		return BPHypertensionStage1
+	case systolic >= 120:
+		return BPElevated
+	default:
+		return BPNormal
+	}
+}
+
+// categorize sets the blood pressure category of a reading, nil without a blood pressure.
+func categorize(reading *Reading) {
+	reading.BPCategory = nil
+	if reading.SystolicMmHg == nil || reading.DiastolicMmHg == nil {
+		return
+	}
+
+	category := classify(float64(*reading.SystolicMmHg), float64(*reading.DiastolicMmHg))
+	reading.BPCategory = &category
+}
@@ -0,0 +1,47 @@
+package vitals
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		systolic  float64
+		diastolic float64
+		want      string
+	}{
+		{name: "normal", systolic: 115, diastolic: 75, want: BPNormal},
+		{name: "elevated from 120 systolic", systolic: 120, diastolic: 79, want: BPElevated},
+		{name: "elevated diastolic does not exist", systolic: 119, diastolic: 79, want: BPNormal},
+		{name: "stage 1 by systolic", systolic: 130, diastolic: 70, want: BPHypertensionStage1},
+		{name: "stage 1 by diastolic", systolic: 118, diastolic: 80, want: BPHypertensionStage1},
+		{name: "stage 2 by systolic", systolic: 140, diastolic: 85, want: BPHypertensionStage2},
+		{name: "stage 2 by diastolic", systolic: 125, diastolic: 90, want: BPHypertensionStage2},
+		{name: "180 systolic is still stage 2", systolic: 180, diastolic: 110, want: BPHypertensionStage2},
+		{name: "crisis by systolic", systolic: 181, diastolic: 100, want: BPHypertensiveCrisis},
+		{name: "crisis by diastolic", systolic: 170, diastolic: 121, want: BPHypertensiveCrisis},
+		{name: "fractional averages", systolic: 129.6, diastolic: 79.9, want: BPElevated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classify(tt.systolic, tt.diastolic); got != tt.want {
+				t.Errorf("classify(%v, %v) = %q, want %q", tt.systolic, tt.diastolic, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategorize(t *testing.T) {
+	systolic, diastolic := 135, 85
+	reading := &Reading{SystolicMmHg: &systolic, DiastolicMmHg: &diastolic}
+	categorize(reading)
+	if reading.BPCategory == nil || *reading.BPCategory != BPHypertensionStage1 {
+		t.Fatalf("categorize set %v, want %q", reading.BPCategory, BPHypertensionStage1)
+	}
+
+	reading.DiastolicMmHg = nil
+	categorize(reading)
+	if reading.BPCategory != nil {
+		t.Errorf("categorize without diastolic set %q, want nil", *reading.BPCategory)
+	}
+}
@@ -0,0 +1,16 @@
+package vitals
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func NewHandlerFactory(db *gorm.DB, logger *zap.Logger) *Handler {
+	repo := NewRepository(db)
+	service := NewService(repo)
+	validator := validator.New()
+	vitalsLogger := logger.Named("VitalsHandler")
+
+	return NewHandler(service, validator, vitalsLogger)
+}
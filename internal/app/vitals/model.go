@@ -0,0 +1,48 @@
+package vitals
+
+import "time"
+
+// Reading is a set of vital signs taken at one time. Every value is optional,
+// but at least one must be present and blood pressure needs both values.
+// BPCategory is classified when the reading is saved.
+type Reading struct {
+	ID                  string    `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID              string    `json:"-" gorm:"type:uuid;not null"`
+	MeasuredAt          time.Time `json:"measured_at" gorm:"not null" validate:"required"`
+	SystolicMmHg        *int      `json:"systolic_mmhg" validate:"required_without_all=PulseBpm RestingHeartRateBpm SpO2Pct TemperatureC,required_with=DiastolicMmHg,omitempty,gte=60,lte=300,gtfield=DiastolicMmHg"`
+	DiastolicMmHg       *int      `json:"diastolic_mmhg" validate:"required_with=SystolicMmHg,omitempty,gte=30,lte=200"`
+	PulseBpm            *int      `json:"pulse_bpm" validate:"omitempty,gte=20,lte=250"`
+	RestingHeartRateBpm *int      `json:"resting_heart_rate_bpm" validate:"omitempty,gte=20,lte=200"`
+	SpO2Pct             *float64  `json:"spo2_pct" gorm:"column:spo2_pct" validate:"omitempty,gte=50,lte=100"`
+	TemperatureC        *float64  `json:"temperature_c" validate:"omitempty,gte=30,lte=45"`
+	BPCategory          *string   `json:"bp_category" gorm:"column:bp_category"`
+	Notes               string    `json:"notes" gorm:"not null;default:''" validate:"max=1000"`
+	CreatedAt           time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Reading) TableName() string {
+	return "vital_readings"
+}
+
+// Stat aggregates one vital sign over a day.
+type Stat struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+// Day aggregates the readings of a local day. Stats are nil without readings of
+// that sign; BPCategory classifies the day's average blood pressure.
+type Day struct {
+	Date                string  `json:"date"`
+	Readings            int     `json:"readings"`
+	SystolicMmHg        *Stat   `json:"systolic_mmhg"`
+	DiastolicMmHg       *Stat   `json:"diastolic_mmhg"`
+	PulseBpm            *Stat   `json:"pulse_bpm"`
+	RestingHeartRateBpm *Stat   `json:"resting_heart_rate_bpm"`
+	SpO2Pct             *Stat   `json:"spo2_pct"`
+	TemperatureC        *Stat   `json:"temperature_c"`
+	BPCategory          *string `json:"bp_category"`
+}
@@ -0,0 +1,140 @@
+package vitals
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+// daysSQL aggregates every vital sign of a user per local day of a time zone.
+const daysSQL = `
+SELECT date_trunc('day', measured_at AT TIME ZONE ?) AS day,
+       COUNT(*)                                      AS readings,
+       COUNT(systolic_mmhg)                          AS systolic_count,
+       AVG(systolic_mmhg)                            AS systolic_avg,
+       MIN(systolic_mmhg)                            AS systolic_min,
+       MAX(systolic_mmhg)                            AS systolic_max,
+       COUNT(diastolic_mmhg)                         AS diastolic_count,
+       AVG(diastolic_mmhg)                           AS diastolic_avg,
+       MIN(diastolic_mmhg)                           AS diastolic_min,
+       MAX(diastolic_mmhg)                           AS diastolic_max,
+       COUNT(pulse_bpm)                              AS pulse_count,
+       AVG(pulse_bpm)                                AS pulse_avg,
+       MIN(pulse_bpm)                                AS pulse_min,
+       MAX(pulse_bpm)                                AS pulse_max,
+       COUNT(resting_heart_rate_bpm)                 AS resting_count,
+       AVG(resting_heart_rate_bpm)                   AS resting_avg,
+       MIN(resting_heart_rate_bpm)                   AS resting_min,
+       MAX(resting_heart_rate_bpm)                   AS resting_max,
+       COUNT(spo2_pct)                               AS spo2_count,
+       AVG(spo2_pct)                                 AS spo2_avg,
+       MIN(spo2_pct)                                 AS spo2_min,
+       MAX(spo2_pct)                                 AS spo2_max,
+       COUNT(temperature_c)                          AS temperature_count,
+       AVG(temperature_c)                            AS temperature_avg,
+       MIN(temperature_c)                            AS temperature_min,
+       MAX(temperature_c)                            AS temperature_max
+FROM vital_readings
+WHERE user_id = ?
+  AND measured_at >= ?
+  AND measured_at < ?
+GROUP BY 1
+ORDER BY 1`
+
+// DayRow is a row of daysSQL. Aggregates of signs without readings are NULL.
+type DayRow struct {
+	Day              time.Time
+	Readings         int
+	SystolicCount    int
+	SystolicAvg      *float64
+	SystolicMin      *float64
+	SystolicMax      *float64
+	DiastolicCount   int
+	DiastolicAvg     *float64
+	DiastolicMin     *float64
+	DiastolicMax     *float64
+	PulseCount       int
+	PulseAvg         *float64
+	PulseMin         *float64
+	PulseMax         *float64
+	RestingCount     int
+	RestingAvg       *float64
+	RestingMin       *float64
+	RestingMax       *float64
+	Spo2Count        int
+	Spo2Avg          *float64
+	Spo2Min          *float64
+	Spo2Max          *float64
+	TemperatureCount int
+	TemperatureAvg   *float64
+	TemperatureMin   *float64
+	TemperatureMax   *float64
+}
+
+type Repository interface {
+	GetAll(userID string, period reporting.Range, limit, offset int) ([]Reading, int64, error)
+	GetByID(userID, id string) (*Reading, error)
+	Create(reading *Reading) error
+	Update(reading *Reading) error
+	Delete(userID, id string) error
+	GetDays(userID string, location *time.Location, period reporting.Range) ([]DayRow, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) GetAll(userID string, period reporting.Range, limit, offset int) ([]Reading, int64, error) {
+	var readings []Reading
+	var total int64
+
+	query := r.db.Model(&Reading{}).Where("user_id = ?", userID)
+	if !period.From.IsZero() {
+		query = query.Where("measured_at >= ?", period.From)
+	}
+	if !period.To.IsZero() {
+		query = query.Where("measured_at < ?", period.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("measured_at DESC").Limit(limit).Offset(offset).Find(&readings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return readings, total, nil
+}
+
+func (r *repositoryImpl) GetByID(userID, id string) (*Reading, error) {
+	var reading Reading
+	if err := r.db.First(&reading, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+func (r *repositoryImpl) Create(reading *Reading) error {
+	return r.db.Create(reading).Error
+}
+
+func (r *repositoryImpl) Update(reading *Reading) error {
+	return r.db.Save(reading).Error
+}
+
+func (r *repositoryImpl) Delete(userID, id string) error {
+	return r.db.Delete(&Reading{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+func (r *repositoryImpl) GetDays(userID string, location *time.Location, period reporting.Range) ([]DayRow, error) {
+	var rows []DayRow
+	err := r.db.Raw(daysSQL, location.String(), userID, period.From, period.To).Scan(&rows).Error
+	return rows, err
+}
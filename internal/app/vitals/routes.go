@@ -0,0 +1,17 @@
+package vitals
+
+import "github.com/go-chi/chi/v5"
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/readings", h.GetAll)
+	r.Post("/readings", h.Create)
+	r.Get("/readings/{id}", h.GetByID)
+	r.Put("/readings/{id}", h.Update)
+	r.Delete("/readings/{id}", h.Delete)
+
+	r.Get("/days", h.GetDays)
+
+	return r
+}
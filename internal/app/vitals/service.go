@@ -0,0 +1,94 @@
+package vitals
+
+import (
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/reporting"
+)
+
+type Service interface {
+	GetAll(userID string, period reporting.Range, limit, offset int) ([]Reading, int64, error)
+	GetByID(userID, id string) (*Reading, error)
+	Create(userID string, reading *Reading) error
+	Update(userID string, reading *Reading) error
+	Delete(userID, id string) error
+
+	// GetDays aggregates the user's readings per local day of the location in the range.
+	GetDays(userID string, location *time.Location, period reporting.Range) ([]Day, error)
+}
+
+type serviceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &serviceImpl{repo: repo}
+}
+
+func (s *serviceImpl) GetAll(userID string, period reporting.Range, limit, offset int) ([]Reading, int64, error) {
+	return s.repo.GetAll(userID, period, limit, offset)
+}
+
+func (s *serviceImpl) GetByID(userID, id string) (*Reading, error) {
+	return s.repo.GetByID(userID, id)
+}
+
+func (s *serviceImpl) Create(userID string, reading *Reading) error {
+	reading.UserID = userID
+	categorize(reading)
+	return s.repo.Create(reading)
+}
+
+func (s *serviceImpl) Update(userID string, reading *Reading) error {
+	existingReading, err := s.repo.GetByID(userID, reading.ID)
+	if err != nil {
+		return err
+	}
+
+	reading.UserID = userID
+	categorize(reading)
+	reading.CreatedAt = existingReading.CreatedAt
+	return s.repo.Update(reading)
+}
+
+func (s *serviceImpl) Delete(userID, id string) error {
+	if _, err := s.repo.GetByID(userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(userID, id)
+}
+
+func (s *serviceImpl) GetDays(userID string, location *time.Location, period reporting.Range) ([]Day, error) {
+	rows, err := s.repo.GetDays(userID, location, period)
+	if err != nil {
+		return nil, err
+	}
+
+	days := []Day{}
+	for _, row := range rows {
+		day := Day{
+			Date:                row.Day.Format(db.DateLayout),
+			Readings:            row.Readings,
+			SystolicMmHg:        stat(row.SystolicCount, row.SystolicAvg, row.SystolicMin, row.SystolicMax),
+			DiastolicMmHg:       stat(row.DiastolicCount, row.DiastolicAvg, row.DiastolicMin, row.DiastolicMax),
+			PulseBpm:            stat(row.PulseCount, row.PulseAvg, row.PulseMin, row.PulseMax),
+			RestingHeartRateBpm: stat(row.RestingCount, row.RestingAvg, row.RestingMin, row.RestingMax),
+			SpO2Pct:             stat(row.Spo2Count, row.Spo2Avg, row.Spo2Min, row.Spo2Max),
+			TemperatureC:        stat(row.TemperatureCount, row.TemperatureAvg, row.TemperatureMin, row.TemperatureMax),
+		}
+		if day.SystolicMmHg != nil && day.DiastolicMmHg != nil {
+			category := classify(day.SystolicMmHg.Average, day.DiastolicMmHg.Average)
+			day.BPCategory = &category
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func stat(count int, average, min, max *float64) *Stat {
+	if count == 0 || average == nil || min == nil || max == nil {
+		return nil
+	}
+	return &Stat{Count: count, Average: reporting.Round(*average, 1), Min: *min, Max: *max}
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+)
+
+// Principal is the authenticated caller: a user and the session its access
+// token belongs to.
+type Principal struct {
+	UserID    string
+	SessionID string
+}
+
+type contextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// PrincipalFrom returns the principal stored by Middleware, if any.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(*Principal)
+	return principal, ok
+}
+
+// RequirePrincipal returns the request's principal. Without one it answers
+// 401 and reports false, and the handler should return.
+func RequirePrincipal(w http.ResponseWriter, r *http.Request) (*Principal, bool) {
+	principal, ok := PrincipalFrom(r.Context())
+	if !ok {
+		errors.WriteHTTPError(w, http.StatusUnauthorized, "Missing access token")
+	}
+	return principal, ok
+}
+
+// RequireUserID returns the ID of the signed-in user like RequirePrincipal.
+func RequireUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	principal, ok := RequirePrincipal(w, r)
+	if !ok {
+		return "", false
+	}
+	return principal.UserID, true
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"github.com/v-vovk/health-tracker-api/internal/infra/logger"
+	"go.uber.org/zap"
+)
+
+// Middleware rejects requests without a valid "Authorization: Bearer" access
+// token and puts the token's principal into the request context.
+func Middleware(issuer *Issuer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				errors.WriteHTTPError(w, http.StatusUnauthorized, "Missing access token")
+				return
+			}
+
+			principal, err := issuer.Parse(token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				errors.WriteHTTPError(w, http.StatusUnauthorized, "Invalid or expired access token")
+				logger.Log.Warn("Rejected access token", zap.String("path", r.URL.Path))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AccessTokenTTL is how long an access token is accepted. Access tokens are
+// not checked against the session store, so logging out or revoking a session
+// takes effect for them when they expire.
+const AccessTokenTTL = 15 * time.Minute
+
+// MinSecretLength is the shortest signing secret accepted, in bytes: HS256 needs
+// a key at least as long as its 256-bit hash.
+const MinSecretLength = 32
+
+// issuer is the "iss" claim of the access tokens.
+const issuer = "health-tracker-api"
+
+var ErrInvalidToken = errors.New("invalid access token")
+
+// claims are the JWT claims of an access token: the user in "sub" and the
+// session the token was issued for in "sid".
+type claims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// Issuer signs and verifies HS256 access tokens.
+type Issuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewIssuer(secret string, ttl time.Duration) *Issuer {
+	return &Issuer{secret: []byte(secret), ttl: ttl}
+}
+
+// Issue signs an access token for the principal, returning it with its expiry.
+func (i *Issuer) Issue(principal Principal, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(i.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		SessionID: principal.SessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   principal.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse verifies an access token and returns its principal.
+func (i *Issuer) Parse(token string) (*Principal, error) {
+	var parsed claims
+	_, err := jwt.ParseWithClaims(token, &parsed,
+		func(*jwt.Token) (interface{}, error) { return i.secret, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || parsed.Subject == "" || parsed.SessionID == "" {
+		return nil, ErrInvalidToken
+	}
+	return &Principal{UserID: parsed.Subject, SessionID: parsed.SessionID}, nil
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// StringArray maps a Postgres text[] column to a Go string slice.
+type StringArray []string
+
+func (StringArray) GormDataType() string {
+	return "text[]"
+}
+
+// Value encodes the slice as a Postgres array literal, quoting every element.
+func (a StringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, s := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan decodes a one-dimensional Postgres array literal such as {a,"b c"}.
+func (a *StringArray) Scan(src interface{}) error {
+	var literal string
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into StringArray", src)
+	}
+
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return fmt.Errorf("invalid array literal %q", literal)
+	}
+
+	result := StringArray{}
+	body := literal[1 : len(literal)-1]
+	for i := 0; i < len(body); {
+		var element strings.Builder
+		quoted := body[i] == '"'
+		if quoted {
+			i++
+			for i < len(body) && body[i] != '"' {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				element.WriteByte(body[i])
+				i++
+			}
+			i++ // closing quote
+		} else {
+			for i < len(body) && body[i] != ',' {
+				element.WriteByte(body[i])
+				i++
+			}
+		}
+		if quoted || element.String() != "NULL" {
+			result = append(result, element.String())
+		}
+		i++ // separator
+	}
+
+	*a = result
+	return nil
+}
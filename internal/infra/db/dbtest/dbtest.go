@@ -0,0 +1,45 @@
+// Package dbtest lets repository tests check the SQL they send without a
+// database: statements are built by the Postgres dialect but never run.
+package dbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Recorder keeps every statement sent, with its values inlined.
+type Recorder struct {
+	logger.Interface
+	Statements []string
+}
+
+func (r *Recorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *Recorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	statement, _ := fc()
+	r.Statements = append(r.Statements, statement)
+}
+
+// Open returns a dry-run connection and the recorder of its statements.
+// Queries find no rows, and explicit transactions need a database and fail.
+func Open(t testing.TB) (*gorm.DB, *Recorder) {
+	t.Helper()
+	recorder := &Recorder{Interface: logger.Discard}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 recorder,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, recorder
+}
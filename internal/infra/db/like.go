@@ -0,0 +1,12 @@
+package db
+
+import "strings"
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern returns a LIKE pattern matching values that contain term
+// literally. Use it with ESCAPE '\'.
+func ContainsPattern(term string) string {
+	return "%" + likeEscaper.Replace(term) + "%"
+}
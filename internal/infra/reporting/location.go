@@ -0,0 +1,48 @@
+package reporting
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+)
+
+type locationKey struct{}
+
+// WithDefaultLocation returns a copy of ctx in which ParseLocation defaults to
+// the location returned by lookup, e.g. the time zone of the user's profile.
+// Lookup runs at most once, and only if a handler needs the default.
+func WithDefaultLocation(ctx context.Context, lookup func() (*time.Location, error)) context.Context {
+	return context.WithValue(ctx, locationKey{}, sync.OnceValues(lookup))
+}
+
+// ParseLocation reads the optional "tz" query parameter, an IANA time zone.
+// Without it the request's default location applies, or UTC if there is none.
+// Failures are answered and reported as false.
+func ParseLocation(w http.ResponseWriter, r *http.Request, logger *zap.Logger) (*time.Location, bool) {
+	tz := r.URL.Query().Get("tz")
+	if tz == "" {
+		lookup, ok := r.Context().Value(locationKey{}).(func() (*time.Location, error))
+		if !ok {
+			return time.UTC, true
+		}
+		location, err := lookup()
+		if err != nil {
+			errors.WriteHTTPError(w, http.StatusInternalServerError, "Error retrieving the profile time zone")
+			logger.Error("Error retrieving the profile time zone", zap.Error(err))
+			return nil, false
+		}
+		return location, true
+	}
+
+	location, err := time.LoadLocation(tz)
+	if err != nil {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "Invalid 'tz' parameter")
+		logger.Warn("Invalid 'tz' parameter", zap.String("tz", tz))
+		return nil, false
+	}
+	return location, true
+}
@@ -0,0 +1,71 @@
+package reporting
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestParseLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := func() (*time.Location, error) { return berlin, nil }
+	broken := func() (*time.Location, error) { return nil, errors.New("no profile") }
+
+	tests := []struct {
+		name     string
+		target   string
+		lookup   func() (*time.Location, error)
+		want     string
+		wantCode int
+	}{
+		{"no default", "/", nil, "UTC", http.StatusOK},
+		{"profile default", "/", profile, "Europe/Berlin", http.StatusOK},
+		{"tz overrides profile", "/?tz=America/New_York", profile, "America/New_York", http.StatusOK},
+		{"invalid tz", "/?tz=Mars/Olympus", profile, "", http.StatusBadRequest},
+		{"profile lookup fails", "/", broken, "", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.lookup != nil {
+				r = r.WithContext(WithDefaultLocation(r.Context(), tt.lookup))
+			}
+			w := httptest.NewRecorder()
+
+			location, ok := ParseLocation(w, r, zap.NewNop())
+			if ok != (tt.wantCode == http.StatusOK) || w.Code != tt.wantCode {
+				t.Fatalf("ParseLocation() ok = %v, code = %d, want code %d", ok, w.Code, tt.wantCode)
+			}
+			if ok && location.String() != tt.want {
+				t.Errorf("ParseLocation() = %v, want %v", location, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRangeInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/?from=2026-03-01&to=2026-03-02", nil)
+	w := httptest.NewRecorder()
+
+	period, ok := ParseRange(w, r, berlin, zap.NewNop())
+	if !ok {
+		t.Fatalf("ParseRange() code = %d, want 200", w.Code)
+	}
+	wantFrom := time.Date(2026, 3, 1, 0, 0, 0, 0, berlin)
+	wantTo := time.Date(2026, 3, 3, 0, 0, 0, 0, berlin)
+	if !period.From.Equal(wantFrom) || !period.To.Equal(wantTo) {
+		t.Errorf("ParseRange() = [%v, %v), want [%v, %v)", period.From, period.To, wantFrom, wantTo)
+	}
+}
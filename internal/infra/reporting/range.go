@@ -0,0 +1,59 @@
+// Package reporting holds what the health modules share to report over time:
+// query ranges, the time zones that split them into days, and rounding.
+package reporting
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/v-vovk/health-tracker-api/internal/infra/db"
+	"github.com/v-vovk/health-tracker-api/internal/infra/errors"
+	"go.uber.org/zap"
+)
+
+// Range limits queries to [From, To). Zero values leave that side open.
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseRange reads the optional "from" and "to" query parameters. Both accept an
+// RFC 3339 timestamp or a plain date in the location; a plain "to" date includes
+// the whole day. An invalid range is answered with 400 and reported as false.
+func ParseRange(w http.ResponseWriter, r *http.Request, location *time.Location, logger *zap.Logger) (Range, bool) {
+	var period Range
+	for _, param := range []string{"from", "to"} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			date, dateErr := db.ParseDate(value)
+			if dateErr != nil {
+				errors.WriteHTTPError(w, http.StatusBadRequest, fmt.Sprintf("Invalid '%s' parameter", param))
+				logger.Warn("Invalid range parameter", zap.String(param, value))
+				return Range{}, false
+			}
+			parsed = date.In(location)
+			if param == "to" {
+				parsed = parsed.AddDate(0, 0, 1)
+			}
+		}
+
+		if param == "from" {
+			period.From = parsed
+		} else {
+			period.To = parsed
+		}
+	}
+
+	if !period.From.IsZero() && !period.To.IsZero() && !period.From.Before(period.To) {
+		errors.WriteHTTPError(w, http.StatusBadRequest, "'from' must be before 'to'")
+		logger.Warn("Empty range", zap.Time("from", period.From), zap.Time("to", period.To))
+		return Range{}, false
+	}
+	return period, true
+}
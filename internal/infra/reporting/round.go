@@ -0,0 +1,9 @@
+package reporting
+
+import "math"
+
+// Round rounds a reported value to the given number of decimal places.
+func Round(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
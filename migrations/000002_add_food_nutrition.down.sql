@@ -0,0 +1,12 @@
+ALTER TABLE foods
+    DROP CONSTRAINT IF EXISTS foods_sugar_within_carbohydrate,
+    DROP CONSTRAINT IF EXISTS foods_saturated_fat_within_fat,
+    DROP COLUMN IF EXISTS sodium_mg,
+    DROP COLUMN IF EXISTS fiber_g,
+    DROP COLUMN IF EXISTS sugar_g,
+    DROP COLUMN IF EXISTS carbohydrate_g,
+    DROP COLUMN IF EXISTS saturated_fat_g,
+    DROP COLUMN IF EXISTS fat_g,
+    DROP COLUMN IF EXISTS protein_g,
+    DROP COLUMN IF EXISTS energy_kj,
+    DROP COLUMN IF EXISTS energy_kcal;
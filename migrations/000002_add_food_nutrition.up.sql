@@ -0,0 +1,13 @@
+-- Nutrition facts per 100 g (sodium in mg)
+ALTER TABLE foods
+    ADD COLUMN energy_kcal     DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (energy_kcal BETWEEN 0 AND 900),
+    ADD COLUMN energy_kj       DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (energy_kj BETWEEN 0 AND 3800),
+    ADD COLUMN protein_g       DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (protein_g BETWEEN 0 AND 100),
+    ADD COLUMN fat_g           DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (fat_g BETWEEN 0 AND 100),
+    ADD COLUMN saturated_fat_g DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (saturated_fat_g BETWEEN 0 AND 100),
+    ADD COLUMN carbohydrate_g  DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (carbohydrate_g BETWEEN 0 AND 100),
+    ADD COLUMN sugar_g         DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (sugar_g BETWEEN 0 AND 100),
+    ADD COLUMN fiber_g         DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (fiber_g BETWEEN 0 AND 100),
+    ADD COLUMN sodium_mg       DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (sodium_mg BETWEEN 0 AND 40000),
+    ADD CONSTRAINT foods_saturated_fat_within_fat CHECK (saturated_fat_g <= fat_g),
+    ADD CONSTRAINT foods_sugar_within_carbohydrate CHECK (sugar_g <= carbohydrate_g);
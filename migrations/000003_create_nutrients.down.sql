@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS food_nutrients;
+DROP TABLE IF EXISTS nutrients;
@@ -0,0 +1,66 @@
+-- Create Nutrient Table
+CREATE TABLE nutrients
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code       TEXT NOT NULL UNIQUE,
+    name       TEXT NOT NULL,
+    category   TEXT NOT NULL CHECK (category IN ('vitamin', 'mineral', 'amino_acid', 'other')),
+    unit       TEXT NOT NULL CHECK (unit IN ('g', 'mg', 'mcg', 'IU')),
+    created_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create FoodNutrient Table (amount per 100 g, in the nutrient's unit)
+CREATE TABLE food_nutrients
+(
+    food_id     UUID             NOT NULL REFERENCES foods (id) ON DELETE CASCADE,
+    nutrient_id UUID             NOT NULL REFERENCES nutrients (id) ON DELETE RESTRICT,
+    amount      DOUBLE PRECISION NOT NULL CHECK (amount >= 0),
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (food_id, nutrient_id)
+);
+
+CREATE INDEX idx_food_nutrients_nutrient_id ON food_nutrients (nutrient_id);
+
+-- Seed the reference catalogue
+INSERT INTO nutrients (code, name, category, unit)
+VALUES ('vitamin_a', 'Vitamin A (RAE)', 'vitamin', 'mcg'),
+       ('vitamin_d', 'Vitamin D', 'vitamin', 'mcg'),
+       ('vitamin_e', 'Vitamin E', 'vitamin', 'mg'),
+       ('vitamin_k', 'Vitamin K', 'vitamin', 'mcg'),
+       ('vitamin_c', 'Vitamin C', 'vitamin', 'mg'),
+       ('thiamin', 'Thiamin (B1)', 'vitamin', 'mg'),
+       ('riboflavin', 'Riboflavin (B2)', 'vitamin', 'mg'),
+       ('niacin', 'Niacin (B3)', 'vitamin', 'mg'),
+       ('pantothenic_acid', 'Pantothenic acid (B5)', 'vitamin', 'mg'),
+       ('vitamin_b6', 'Vitamin B6', 'vitamin', 'mg'),
+       ('biotin', 'Biotin (B7)', 'vitamin', 'mcg'),
+       ('folate', 'Folate (DFE)', 'vitamin', 'mcg'),
+       ('vitamin_b12', 'Vitamin B12', 'vitamin', 'mcg'),
+       ('choline', 'Choline', 'vitamin', 'mg'),
+       ('calcium', 'Calcium', 'mineral', 'mg'),
+       ('iron', 'Iron', 'mineral', 'mg'),
+       ('magnesium', 'Magnesium', 'mineral', 'mg'),
+       ('phosphorus', 'Phosphorus', 'mineral', 'mg'),
+       ('potassium', 'Potassium', 'mineral', 'mg'),
+       ('zinc', 'Zinc', 'mineral', 'mg'),
+       ('copper', 'Copper', 'mineral', 'mg'),
+       ('manganese', 'Manganese', 'mineral', 'mg'),
+       ('selenium', 'Selenium', 'mineral', 'mcg'),
+       ('iodine', 'Iodine', 'mineral', 'mcg'),
+       ('chromium', 'Chromium', 'mineral', 'mcg'),
+       ('molybdenum', 'Molybdenum', 'mineral', 'mcg'),
+       ('fluoride', 'Fluoride', 'mineral', 'mg'),
+       ('chloride', 'Chloride', 'mineral', 'mg'),
+       ('histidine', 'Histidine', 'amino_acid', 'g'),
+       ('isoleucine', 'Isoleucine', 'amino_acid', 'g'),
+       ('leucine', 'Leucine', 'amino_acid', 'g'),
+       ('lysine', 'Lysine', 'amino_acid', 'g'),
+       ('methionine', 'Methionine', 'amino_acid', 'g'),
+       ('phenylalanine', 'Phenylalanine', 'amino_acid', 'g'),
+       ('threonine', 'Threonine', 'amino_acid', 'g'),
+       ('tryptophan', 'Tryptophan', 'amino_acid', 'g'),
+       ('valine', 'Valine', 'amino_acid', 'g'),
+       ('cholesterol', 'Cholesterol', 'other', 'mg'),
+       ('caffeine', 'Caffeine', 'other', 'mg');
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS food_portions;
+
+ALTER TABLE foods
+    DROP COLUMN IF EXISTS density_g_per_ml;
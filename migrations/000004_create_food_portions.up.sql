@@ -0,0 +1,16 @@
+-- Density is needed to convert volume units (ml, cup, ...) into grams
+ALTER TABLE foods
+    ADD COLUMN density_g_per_ml DOUBLE PRECISION CHECK (density_g_per_ml > 0);
+
+-- Create FoodPortion Table
+CREATE TABLE food_portions
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    food_id    UUID             NOT NULL REFERENCES foods (id) ON DELETE CASCADE,
+    name       TEXT             NOT NULL,
+    grams      DOUBLE PRECISION NOT NULL CHECK (grams > 0),
+    created_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_food_portions_food_id_name ON food_portions (food_id, lower(name));
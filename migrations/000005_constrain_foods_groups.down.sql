@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_foods_groups_group_id;
+
+ALTER TABLE foods_groups
+    DROP CONSTRAINT IF EXISTS foods_groups_max_size_check,
+    DROP CONSTRAINT IF EXISTS foods_groups_food_id_group_id_key,
+    DROP CONSTRAINT IF EXISTS foods_groups_group_id_fkey,
+    DROP CONSTRAINT IF EXISTS foods_groups_food_id_fkey,
+    ADD CONSTRAINT foods_groups_food_id_fkey FOREIGN KEY (food_id) REFERENCES foods (id),
+    ADD CONSTRAINT foods_groups_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id),
+    ALTER COLUMN group_id DROP NOT NULL,
+    ALTER COLUMN food_id DROP NOT NULL;
@@ -0,0 +1,39 @@
+-- Drop memberships missing their food or group; they cannot be kept under NOT NULL
+DELETE FROM foods_groups
+WHERE food_id IS NULL
+   OR group_id IS NULL;
+
+-- Merge duplicate memberships into the earliest one, keeping the largest size
+WITH ranked AS (SELECT id,
+                       row_number() OVER (PARTITION BY food_id, group_id ORDER BY created_at, id) AS n,
+                       max(max_size) OVER (PARTITION BY food_id, group_id)                        AS merged_size
+                FROM foods_groups)
+UPDATE foods_groups f
+SET max_size = r.merged_size
+FROM ranked r
+WHERE f.id = r.id
+  AND r.n = 1;
+
+DELETE FROM foods_groups f
+    USING (SELECT id, row_number() OVER (PARTITION BY food_id, group_id ORDER BY created_at, id) AS n
+           FROM foods_groups) r
+WHERE f.id = r.id
+  AND r.n > 1;
+
+-- Sizes must be positive; clamp the rest to the smallest whole size
+UPDATE foods_groups
+SET max_size = 1
+WHERE max_size <= 0;
+
+-- Memberships always link an existing food to an existing group, at most once
+ALTER TABLE foods_groups
+    ALTER COLUMN food_id SET NOT NULL,
+    ALTER COLUMN group_id SET NOT NULL,
+    DROP CONSTRAINT foods_groups_food_id_fkey,
+    DROP CONSTRAINT foods_groups_group_id_fkey,
+    ADD CONSTRAINT foods_groups_food_id_fkey FOREIGN KEY (food_id) REFERENCES foods (id) ON DELETE CASCADE,
+    ADD CONSTRAINT foods_groups_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE,
+    ADD CONSTRAINT foods_groups_food_id_group_id_key UNIQUE (food_id, group_id),
+    ADD CONSTRAINT foods_groups_max_size_check CHECK (max_size > 0);
+
+CREATE INDEX idx_foods_groups_group_id ON foods_groups (group_id);
@@ -0,0 +1,6 @@
+-- Groups form a tree; a group with subgroups cannot be deleted
+ALTER TABLE groups
+    ADD COLUMN parent_id UUID REFERENCES groups (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT groups_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_groups_parent_id ON groups (parent_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_foods_name_trgm;
+DROP INDEX IF EXISTS idx_foods_search_vector;
+
+ALTER TABLE foods
+    DROP COLUMN IF EXISTS search_vector;
+
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
@@ -0,0 +1,20 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() is only STABLE; generated columns and index expressions need an IMMUTABLE function
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+    STRICT
+AS
+$$
+SELECT public.unaccent('public.unaccent'::regdictionary, $1)
+$$;
+
+ALTER TABLE foods
+    ADD COLUMN search_vector tsvector
+        GENERATED ALWAYS AS (to_tsvector('simple', immutable_unaccent(name))) STORED;
+
+CREATE INDEX idx_foods_search_vector ON foods USING GIN (search_vector);
+CREATE INDEX idx_foods_name_trgm ON foods USING GIN (immutable_unaccent(lower(name)) gin_trgm_ops);
@@ -0,0 +1,5 @@
+-- Barcodes are stored as GTIN-14 (EAN-8, UPC-A and EAN-13 are left-padded with zeros)
+ALTER TABLE foods
+    ADD COLUMN barcode TEXT CHECK (barcode ~ '^[0-9]{14}$');
+
+CREATE UNIQUE INDEX idx_foods_barcode ON foods (barcode);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_foods_brand_id;
+
+ALTER TABLE foods
+    DROP COLUMN IF EXISTS brand_id;
+
+DROP TABLE IF EXISTS brands;
+DROP TABLE IF EXISTS manufacturers;
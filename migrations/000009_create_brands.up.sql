@@ -0,0 +1,30 @@
+-- Create Manufacturer Table
+CREATE TABLE manufacturers
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT NOT NULL,
+    country    TEXT NOT NULL    DEFAULT '',
+    website    TEXT NOT NULL    DEFAULT '',
+    created_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_manufacturers_name ON manufacturers (lower(name));
+
+-- Create Brand Table
+CREATE TABLE brands
+(
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name            TEXT NOT NULL,
+    manufacturer_id UUID NOT NULL REFERENCES manufacturers (id) ON DELETE RESTRICT,
+    created_at      TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_brands_manufacturer_id_name ON brands (manufacturer_id, lower(name));
+
+-- Packaged foods belong to a brand
+ALTER TABLE foods
+    ADD COLUMN brand_id UUID REFERENCES brands (id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_foods_brand_id ON foods (brand_id);
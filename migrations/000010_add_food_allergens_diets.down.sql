@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_foods_diets;
+DROP INDEX IF EXISTS idx_foods_allergens;
+
+ALTER TABLE foods
+    DROP COLUMN IF EXISTS diets,
+    DROP COLUMN IF EXISTS allergens;
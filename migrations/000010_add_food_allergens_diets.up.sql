@@ -0,0 +1,10 @@
+-- EU regulated allergens (Regulation No 1169/2011, Annex II) and dietary flags
+ALTER TABLE foods
+    ADD COLUMN allergens TEXT[] NOT NULL DEFAULT '{}'
+        CHECK (allergens <@ ARRAY ['gluten', 'crustaceans', 'eggs', 'fish', 'peanuts', 'soybeans', 'milk', 'nuts',
+            'celery', 'mustard', 'sesame', 'sulphites', 'lupin', 'molluscs']),
+    ADD COLUMN diets     TEXT[] NOT NULL DEFAULT '{}'
+        CHECK (diets <@ ARRAY ['vegan', 'vegetarian', 'gluten_free', 'halal', 'kosher']);
+
+CREATE INDEX idx_foods_allergens ON foods USING GIN (allergens);
+CREATE INDEX idx_foods_diets ON foods USING GIN (diets);
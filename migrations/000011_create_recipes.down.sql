@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS recipe_ingredients;
+
+-- Recipe foods have no meaning without their recipe
+DELETE FROM foods WHERE id IN (SELECT food_id FROM recipes);
+
+DROP TABLE IF EXISTS recipes;
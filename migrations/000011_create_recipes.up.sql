@@ -0,0 +1,26 @@
+-- Every recipe owns a food row holding its name and computed nutrition
+CREATE TABLE recipes
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    food_id      UUID             NOT NULL UNIQUE REFERENCES foods (id) ON DELETE CASCADE,
+    servings     DOUBLE PRECISION NOT NULL CHECK (servings > 0),
+    yield_grams  DOUBLE PRECISION CHECK (yield_grams > 0),
+    instructions TEXT             NOT NULL DEFAULT '',
+    created_at   TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Ingredient foods cannot be deleted while a recipe uses them
+CREATE TABLE recipe_ingredients
+(
+    id        UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recipe_id UUID             NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    food_id   UUID             NOT NULL REFERENCES foods (id) ON DELETE RESTRICT,
+    amount    DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    unit      TEXT             NOT NULL,
+    grams     DOUBLE PRECISION NOT NULL CHECK (grams > 0),
+    position  INT              NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_recipe_ingredients_recipe_id ON recipe_ingredients (recipe_id, position);
+CREATE INDEX idx_recipe_ingredients_food_id ON recipe_ingredients (food_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS diary_entries;
+DROP TABLE IF EXISTS meals;
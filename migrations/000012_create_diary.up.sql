@@ -0,0 +1,34 @@
+-- Create Meal Table
+CREATE TABLE meals
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID        NOT NULL,
+    type        TEXT        NOT NULL CHECK (type IN ('breakfast', 'lunch', 'dinner', 'snack', 'custom')),
+    name        TEXT        NOT NULL DEFAULT '',
+    consumed_at TIMESTAMPTZ NOT NULL,
+    notes       TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    CHECK (type <> 'custom' OR name <> '')
+);
+
+CREATE INDEX idx_meals_user_id_consumed_at ON meals (user_id, consumed_at);
+
+-- Logged foods cannot be deleted while diary entries reference them
+CREATE TABLE diary_entries
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID             NOT NULL,
+    meal_id     UUID             NOT NULL REFERENCES meals (id) ON DELETE CASCADE,
+    food_id     UUID             NOT NULL REFERENCES foods (id) ON DELETE RESTRICT,
+    amount      DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    unit        TEXT             NOT NULL,
+    grams       DOUBLE PRECISION NOT NULL CHECK (grams > 0),
+    consumed_at TIMESTAMPTZ      NOT NULL,
+    created_at  TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_diary_entries_meal_id ON diary_entries (meal_id);
+CREATE INDEX idx_diary_entries_food_id ON diary_entries (food_id);
+CREATE INDEX idx_diary_entries_user_id_consumed_at ON diary_entries (user_id, consumed_at);
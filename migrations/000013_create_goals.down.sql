@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS goal_targets;
+DROP TABLE IF EXISTS goals;
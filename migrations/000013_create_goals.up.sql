@@ -0,0 +1,24 @@
+-- A goal applies from effective_from until the user's next goal starts
+CREATE TABLE goals
+(
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        UUID      NOT NULL,
+    effective_from DATE      NOT NULL,
+    notes          TEXT      NOT NULL DEFAULT '',
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, effective_from)
+);
+
+-- Targets are either an absolute amount or, for macronutrients, a share of energy
+CREATE TABLE goal_targets
+(
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    goal_id        UUID NOT NULL REFERENCES goals (id) ON DELETE CASCADE,
+    nutrient       TEXT NOT NULL,
+    type           TEXT NOT NULL DEFAULT 'target' CHECK (type IN ('target', 'min', 'max')),
+    amount         DOUBLE PRECISION CHECK (amount > 0),
+    percent_energy DOUBLE PRECISION CHECK (percent_energy > 0 AND percent_energy <= 100),
+    CHECK ((amount IS NULL) <> (percent_energy IS NULL)),
+    UNIQUE (goal_id, nutrient)
+);
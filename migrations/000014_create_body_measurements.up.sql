@@ -0,0 +1,18 @@
+-- Create BodyMeasurement Table
+CREATE TABLE body_measurements
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID        NOT NULL,
+    measured_at  TIMESTAMPTZ NOT NULL,
+    weight_kg    DOUBLE PRECISION CHECK (weight_kg > 0 AND weight_kg <= 700),
+    body_fat_pct DOUBLE PRECISION CHECK (body_fat_pct > 0 AND body_fat_pct < 100),
+    waist_cm     DOUBLE PRECISION CHECK (waist_cm > 0 AND waist_cm <= 500),
+    hip_cm       DOUBLE PRECISION CHECK (hip_cm > 0 AND hip_cm <= 500),
+    chest_cm     DOUBLE PRECISION CHECK (chest_cm > 0 AND chest_cm <= 500),
+    notes        TEXT        NOT NULL DEFAULT '',
+    created_at   TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    CHECK (COALESCE(weight_kg, body_fat_pct, waist_cm, hip_cm, chest_cm) IS NOT NULL)
+);
+
+CREATE INDEX idx_body_measurements_user_id_measured_at ON body_measurements (user_id, measured_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS hydration_targets;
+DROP TABLE IF EXISTS hydration_logs;
+
+ALTER TABLE foods
+    DROP COLUMN IF EXISTS water_factor;
@@ -0,0 +1,29 @@
+-- Share of a beverage's weight that counts as water intake
+ALTER TABLE foods
+    ADD COLUMN water_factor DOUBLE PRECISION CHECK (water_factor > 0 AND water_factor <= 1);
+
+-- Create HydrationLog Table
+CREATE TABLE hydration_logs
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID             NOT NULL,
+    logged_at  TIMESTAMPTZ      NOT NULL,
+    volume_ml  DOUBLE PRECISION NOT NULL CHECK (volume_ml > 0),
+    note       TEXT             NOT NULL DEFAULT '',
+    created_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_hydration_logs_user_id_logged_at ON hydration_logs (user_id, logged_at);
+
+-- A target applies from effective_from until the user's next target starts
+CREATE TABLE hydration_targets
+(
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        UUID             NOT NULL,
+    effective_from DATE             NOT NULL,
+    volume_ml      DOUBLE PRECISION NOT NULL CHECK (volume_ml > 0),
+    created_at     TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, effective_from)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS activity_sessions;
+DROP TABLE IF EXISTS activity_types;
@@ -0,0 +1,64 @@
+-- Create ActivityType Table
+CREATE TABLE activity_types
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code       TEXT             NOT NULL UNIQUE,
+    name       TEXT             NOT NULL,
+    category   TEXT             NOT NULL,
+    met        DOUBLE PRECISION NOT NULL CHECK (met >= 0.9 AND met <= 25),
+    created_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Energy is stored as estimated when the session was saved
+CREATE TABLE activity_sessions
+(
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id          UUID             NOT NULL,
+    activity_type_id UUID             NOT NULL REFERENCES activity_types (id) ON DELETE RESTRICT,
+    started_at       TIMESTAMPTZ      NOT NULL,
+    duration_min     DOUBLE PRECISION NOT NULL CHECK (duration_min > 0 AND duration_min <= 1440),
+    intensity        TEXT             NOT NULL DEFAULT 'moderate' CHECK (intensity IN ('light', 'moderate', 'vigorous')),
+    weight_kg        DOUBLE PRECISION NOT NULL CHECK (weight_kg > 0),
+    met              DOUBLE PRECISION NOT NULL CHECK (met > 0),
+    energy_kcal      DOUBLE PRECISION NOT NULL CHECK (energy_kcal >= 0),
+    notes            TEXT             NOT NULL DEFAULT '',
+    created_at       TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_activity_sessions_user_id_started_at ON activity_sessions (user_id, started_at);
+
+-- Seed common activities from the 2011 Compendium of Physical Activities
+INSERT INTO activity_types (code, name, category, met)
+VALUES ('01010', 'Bicycling, leisure, <10 mph', 'bicycling', 4.0),
+       ('01015', 'Bicycling, general', 'bicycling', 7.5),
+       ('01040', 'Bicycling, 12-13.9 mph, moderate effort', 'bicycling', 8.0),
+       ('01050', 'Bicycling, 14-15.9 mph, vigorous effort', 'bicycling', 10.0),
+       ('02010', 'Bicycling, stationary, general', 'conditioning exercise', 7.0),
+       ('02048', 'Elliptical trainer, moderate effort', 'conditioning exercise', 5.0),
+       ('02050', 'Resistance training, multiple exercises, vigorous effort', 'conditioning exercise', 6.0),
+       ('02054', 'Resistance training, moderate effort', 'conditioning exercise', 3.5),
+       ('02071', 'Rowing, stationary, general, moderate effort', 'conditioning exercise', 4.8),
+       ('02105', 'Pilates, general', 'conditioning exercise', 3.0),
+       ('02150', 'Yoga, Hatha', 'conditioning exercise', 2.5),
+       ('03015', 'Aerobic, general', 'dancing', 7.3),
+       ('12020', 'Jogging, general', 'running', 7.0),
+       ('12030', 'Running, 5 mph (12 min/mile)', 'running', 8.3),
+       ('12050', 'Running, 6 mph (10 min/mile)', 'running', 9.8),
+       ('12070', 'Running, 7 mph (8.5 min/mile)', 'running', 11.0),
+       ('12090', 'Running, 8 mph (7.5 min/mile)', 'running', 11.8),
+       ('12150', 'Running, general', 'running', 8.0),
+       ('15030', 'Badminton, general', 'sports', 5.5),
+       ('15055', 'Basketball, general', 'sports', 6.5),
+       ('15255', 'Golf, general', 'sports', 4.8),
+       ('15610', 'Soccer, casual, general', 'sports', 7.0),
+       ('15675', 'Tennis, general', 'sports', 7.3),
+       ('17080', 'Hiking, cross country', 'walking', 6.0),
+       ('17160', 'Walking for pleasure', 'walking', 3.5),
+       ('17190', 'Walking, 2.8-3.2 mph, level, moderate pace', 'walking', 3.5),
+       ('17200', 'Walking, 3.5 mph, level, brisk', 'walking', 4.3),
+       ('17220', 'Walking, 4.0 mph, level, very brisk', 'walking', 5.0),
+       ('18230', 'Swimming laps, freestyle, fast, vigorous effort', 'water activities', 9.8),
+       ('18240', 'Swimming laps, freestyle, light or moderate effort', 'water activities', 5.8),
+       ('18350', 'Swimming, leisurely, general', 'water activities', 6.0);
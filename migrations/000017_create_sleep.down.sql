@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sleep_stages;
+DROP TABLE IF EXISTS sleep_sessions;
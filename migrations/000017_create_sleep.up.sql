@@ -0,0 +1,35 @@
+-- Create SleepSession Table
+CREATE TABLE sleep_sessions
+(
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID             NOT NULL,
+    started_at      TIMESTAMPTZ      NOT NULL,
+    ended_at        TIMESTAMPTZ      NOT NULL,
+    latency_min     DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (latency_min >= 0),
+    awake_min       DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (awake_min >= 0),
+    quality         INTEGER CHECK (quality BETWEEN 1 AND 5),
+    time_in_bed_min DOUBLE PRECISION NOT NULL,
+    total_sleep_min DOUBLE PRECISION NOT NULL CHECK (total_sleep_min >= 0),
+    efficiency      DOUBLE PRECISION NOT NULL CHECK (efficiency >= 0 AND efficiency <= 100),
+    notes           TEXT             NOT NULL DEFAULT '',
+    created_at      TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ended_at > started_at AND ended_at <= started_at + INTERVAL '24 hours')
+);
+
+CREATE INDEX idx_sleep_sessions_user_id_ended_at ON sleep_sessions (user_id, ended_at);
+
+-- Create SleepStage Table
+CREATE TABLE sleep_stages
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID        NOT NULL REFERENCES sleep_sessions (id) ON DELETE CASCADE,
+    type       TEXT        NOT NULL CHECK (type IN ('awake', 'light', 'deep', 'rem')),
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at   TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ended_at > started_at)
+);
+
+CREATE INDEX idx_sleep_stages_session_id ON sleep_stages (session_id);
@@ -0,0 +1,17 @@
+-- Create GlucoseReading Table
+CREATE TABLE glucose_readings
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID             NOT NULL,
+    measured_at TIMESTAMPTZ      NOT NULL,
+    value       DOUBLE PRECISION NOT NULL CHECK (value > 0),
+    unit        TEXT             NOT NULL CHECK (unit IN ('mg/dL', 'mmol/L')),
+    mg_dl       DOUBLE PRECISION NOT NULL CHECK (mg_dl >= 10 AND mg_dl <= 1000),
+    mmol_l      DOUBLE PRECISION NOT NULL,
+    tag         TEXT             NOT NULL DEFAULT 'random' CHECK (tag IN ('fasting', 'pre_meal', 'post_meal', 'random')),
+    notes       TEXT             NOT NULL DEFAULT '',
+    created_at  TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_glucose_readings_user_id_measured_at ON glucose_readings (user_id, measured_at);
@@ -0,0 +1,23 @@
+-- Create VitalReading Table
+CREATE TABLE vital_readings
+(
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id                UUID        NOT NULL,
+    measured_at            TIMESTAMPTZ NOT NULL,
+    systolic_mmhg          INTEGER CHECK (systolic_mmhg BETWEEN 60 AND 300),
+    diastolic_mmhg         INTEGER CHECK (diastolic_mmhg BETWEEN 30 AND 200),
+    pulse_bpm              INTEGER CHECK (pulse_bpm BETWEEN 20 AND 250),
+    resting_heart_rate_bpm INTEGER CHECK (resting_heart_rate_bpm BETWEEN 20 AND 200),
+    spo2_pct               DOUBLE PRECISION CHECK (spo2_pct BETWEEN 50 AND 100),
+    temperature_c          DOUBLE PRECISION CHECK (temperature_c BETWEEN 30 AND 45),
+    bp_category            TEXT CHECK (bp_category IN ('normal', 'elevated', 'hypertension_stage_1',
+                                                       'hypertension_stage_2', 'hypertensive_crisis')),
+    notes                  TEXT        NOT NULL DEFAULT '',
+    created_at             TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((systolic_mmhg IS NULL) = (diastolic_mmhg IS NULL)),
+    CHECK (systolic_mmhg IS NULL OR systolic_mmhg > diastolic_mmhg),
+    CHECK (COALESCE(systolic_mmhg, pulse_bpm, resting_heart_rate_bpm, spo2_pct, temperature_c) IS NOT NULL)
+);
+
+CREATE INDEX idx_vital_readings_user_id_measured_at ON vital_readings (user_id, measured_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS medication_doses;
+DROP TABLE IF EXISTS medication_nutrients;
+DROP TABLE IF EXISTS medications;
@@ -0,0 +1,53 @@
+-- Create Medication Table
+CREATE TABLE medications
+(
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        UUID             NOT NULL,
+    name           TEXT             NOT NULL,
+    kind           TEXT             NOT NULL CHECK (kind IN ('medication', 'supplement')),
+    dose           DOUBLE PRECISION NOT NULL CHECK (dose > 0),
+    unit           TEXT             NOT NULL,
+    schedule       TEXT             NOT NULL CHECK (schedule IN ('daily', 'interval', 'weekdays')),
+    times          TEXT[]           NOT NULL DEFAULT '{}',
+    interval_hours INTEGER CHECK (interval_hours BETWEEN 1 AND 168),
+    weekdays       TEXT[]           NOT NULL DEFAULT '{}',
+    time_zone      TEXT             NOT NULL DEFAULT 'UTC',
+    start_date     DATE             NOT NULL,
+    end_date       DATE,
+    notes          TEXT             NOT NULL DEFAULT '',
+    created_at     TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    CHECK (schedule <> 'interval' OR interval_hours IS NOT NULL),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_medications_user_id ON medications (user_id);
+
+-- Amount of a nutrient in one dose of a supplement
+CREATE TABLE medication_nutrients
+(
+    medication_id UUID             NOT NULL REFERENCES medications (id) ON DELETE CASCADE,
+    nutrient_id   UUID             NOT NULL REFERENCES nutrients (id) ON DELETE CASCADE,
+    amount        DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    created_at    TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (medication_id, nutrient_id)
+);
+
+-- One record per scheduled dose
+CREATE TABLE medication_doses
+(
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    medication_id UUID        NOT NULL REFERENCES medications (id) ON DELETE CASCADE,
+    scheduled_at  TIMESTAMPTZ NOT NULL,
+    status        TEXT        NOT NULL CHECK (status IN ('taken', 'skipped')),
+    taken_at      TIMESTAMPTZ,
+    note          TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (medication_id, scheduled_at),
+    CHECK ((status = 'taken') = (taken_at IS NOT NULL))
+);
+
+CREATE INDEX idx_medication_doses_scheduled_at ON medication_doses (scheduled_at);
+CREATE INDEX idx_medication_doses_taken_at ON medication_doses (taken_at);
@@ -0,0 +1,16 @@
+-- Create Symptom Table
+CREATE TABLE symptoms
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID        NOT NULL,
+    type         TEXT        NOT NULL CHECK (type <> '' AND type = lower(type)),
+    severity     INTEGER     NOT NULL CHECK (severity BETWEEN 0 AND 10),
+    onset_at     TIMESTAMPTZ NOT NULL,
+    duration_min DOUBLE PRECISION CHECK (duration_min > 0),
+    notes        TEXT        NOT NULL DEFAULT '',
+    created_at   TIMESTAMP   DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP   DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_symptoms_user_id_onset_at ON symptoms (user_id, onset_at);
+CREATE INDEX idx_symptoms_type ON symptoms (type);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS fasting_sessions;
@@ -0,0 +1,20 @@
+-- Create Fasting Session Table
+CREATE TABLE fasting_sessions
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID             NOT NULL,
+    protocol     TEXT             NOT NULL CHECK (protocol IN ('16:8', '18:6', 'omad', 'custom')),
+    target_hours DOUBLE PRECISION NOT NULL CHECK (target_hours > 0 AND target_hours <= 168),
+    started_at   TIMESTAMPTZ      NOT NULL,
+    ended_at     TIMESTAMPTZ CHECK (ended_at >= started_at),
+    end_reason   TEXT             NOT NULL DEFAULT '' CHECK (end_reason IN ('', 'manual', 'diary_entry')),
+    notes        TEXT             NOT NULL DEFAULT '',
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((ended_at IS NULL) = (end_reason = ''))
+);
+
+CREATE INDEX idx_fasting_sessions_user_id_started_at ON fasting_sessions (user_id, started_at);
+
+-- At most one fast per user is in progress at a time.
+CREATE UNIQUE INDEX idx_fasting_sessions_open ON fasting_sessions (user_id) WHERE ended_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS achievement_badges;
+DROP TABLE IF EXISTS achievement_days;
+DROP TABLE IF EXISTS achievement_rules;
@@ -0,0 +1,40 @@
+-- Create Achievement Rule Table
+CREATE TABLE achievement_rules
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID      NOT NULL,
+    name        TEXT      NOT NULL,
+    description TEXT      NOT NULL DEFAULT '',
+    kind        TEXT      NOT NULL CHECK (kind IN ('meals_logged', 'goal_met')),
+    meal_types  TEXT[]    NOT NULL DEFAULT '{}',
+    nutrient    TEXT      NOT NULL DEFAULT '',
+    mode        TEXT      NOT NULL DEFAULT 'streak' CHECK (mode IN ('streak', 'total')),
+    days        INTEGER   NOT NULL CHECK (days BETWEEN 1 AND 3650),
+    time_zone   TEXT      NOT NULL DEFAULT 'UTC',
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((kind = 'meals_logged') = (cardinality(meal_types) > 0)),
+    CHECK ((kind = 'goal_met') = (nutrient <> ''))
+);
+
+CREATE INDEX idx_achievement_rules_user_id ON achievement_rules (user_id);
+
+-- Create Achievement Day Table
+CREATE TABLE achievement_days
+(
+    rule_id UUID NOT NULL REFERENCES achievement_rules (id) ON DELETE CASCADE,
+    date    DATE NOT NULL,
+    PRIMARY KEY (rule_id, date)
+);
+
+-- Create Achievement Badge Table
+CREATE TABLE achievement_badges
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id    UUID      NOT NULL UNIQUE REFERENCES achievement_rules (id) ON DELETE CASCADE,
+    date       DATE      NOT NULL,
+    days       INTEGER   NOT NULL CHECK (days > 0),
+    awarded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_achievement_badges_awarded_at ON achievement_badges (awarded_at);
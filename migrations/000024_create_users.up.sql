@@ -0,0 +1,17 @@
+-- Create User Table
+CREATE TABLE users
+(
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email         TEXT      NOT NULL CHECK (email <> ''),
+    password_hash TEXT      NOT NULL,
+    name          TEXT      NOT NULL DEFAULT '',
+    time_zone     TEXT      NOT NULL DEFAULT 'UTC',
+    failed_logins INTEGER   NOT NULL DEFAULT 0 CHECK (failed_logins >= 0),
+    locked_until  TIMESTAMPTZ,
+    last_login_at TIMESTAMPTZ,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Emails are unique regardless of case.
+CREATE UNIQUE INDEX idx_users_email ON users (lower(email));
@@ -0,0 +1,28 @@
+-- Create Session Table
+CREATE TABLE sessions
+(
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id       UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_agent    TEXT        NOT NULL DEFAULT '',
+    ip_address    TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at  TIMESTAMPTZ NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL,
+    revoked_at    TIMESTAMPTZ,
+    revoke_reason TEXT        NOT NULL DEFAULT '' CHECK (revoke_reason IN ('', 'logout', 'revoked', 'reuse_detected'))
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+
+-- Create Refresh Token Table
+CREATE TABLE refresh_tokens
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID        NOT NULL REFERENCES sessions (id) ON DELETE CASCADE,
+    token_hash TEXT        NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
@@ -0,0 +1,4 @@
+UPDATE activity_sessions
+SET met         = round((met * CASE intensity WHEN 'light' THEN 0.8 ELSE 1.2 END)::numeric, 2),
+    energy_kcal = round((energy_kcal * CASE intensity WHEN 'light' THEN 0.8 ELSE 1.2 END)::numeric, 1)
+WHERE intensity IN ('light', 'vigorous');
@@ -0,0 +1,2 @@
+ALTER TABLE foods
+    DROP COLUMN IF EXISTS allergens_verified;
@@ -0,0 +1,7 @@
+-- Distinguish foods declared free of allergens from foods whose allergens were never entered
+ALTER TABLE foods
+    ADD COLUMN allergens_verified BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE foods
+SET allergens_verified = true
+WHERE allergens <> '{}';
@@ -0,0 +1,28 @@
+ALTER TABLE achievement_rules
+    DROP CONSTRAINT IF EXISTS achievement_rules_user_id_fkey;
+ALTER TABLE fasting_sessions
+    DROP CONSTRAINT IF EXISTS fasting_sessions_user_id_fkey;
+ALTER TABLE symptoms
+    DROP CONSTRAINT IF EXISTS symptoms_user_id_fkey;
+ALTER TABLE medications
+    DROP CONSTRAINT IF EXISTS medications_user_id_fkey;
+ALTER TABLE vital_readings
+    DROP CONSTRAINT IF EXISTS vital_readings_user_id_fkey;
+ALTER TABLE glucose_readings
+    DROP CONSTRAINT IF EXISTS glucose_readings_user_id_fkey;
+ALTER TABLE sleep_sessions
+    DROP CONSTRAINT IF EXISTS sleep_sessions_user_id_fkey;
+ALTER TABLE activity_sessions
+    DROP CONSTRAINT IF EXISTS activity_sessions_user_id_fkey;
+ALTER TABLE hydration_targets
+    DROP CONSTRAINT IF EXISTS hydration_targets_user_id_fkey;
+ALTER TABLE hydration_logs
+    DROP CONSTRAINT IF EXISTS hydration_logs_user_id_fkey;
+ALTER TABLE body_measurements
+    DROP CONSTRAINT IF EXISTS body_measurements_user_id_fkey;
+ALTER TABLE goals
+    DROP CONSTRAINT IF EXISTS goals_user_id_fkey;
+ALTER TABLE diary_entries
+    DROP CONSTRAINT IF EXISTS diary_entries_user_id_fkey;
+ALTER TABLE meals
+    DROP CONSTRAINT IF EXISTS meals_user_id_fkey;